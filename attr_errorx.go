@@ -0,0 +1,193 @@
+package errorsx
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+)
+
+// badKey is the key given to arguments of With that don't follow a string
+// key, the way slog does.
+const badKey = "!BADKEY"
+
+// Attributer is implemented by errors that carry arbitrary attributes.
+type Attributer interface {
+	Attributes() map[string]any
+}
+
+type attrErrorX struct {
+	ErrorX
+
+	attrs map[string]any
+}
+
+var _ Attributer = (*attrErrorX)(nil)
+
+func (e *attrErrorX) Error() string {
+	return stringify(e)
+}
+
+func (e *attrErrorX) Unwrap() error {
+	return e.Inner()
+}
+
+func (e attrErrorX) Wrap(err error) ErrorX {
+	e.ErrorX = e.ErrorX.Wrap(err)
+	return &e
+}
+
+func (e *attrErrorX) With(args ...any) ErrorX {
+	return With(e, args...)
+}
+
+func (e *attrErrorX) Attributes() map[string]any {
+	return maps.Clone(e.attrs)
+}
+
+func (e *attrErrorX) LayerMessage() string {
+	return ""
+}
+
+func (e *attrErrorX) Fields(fields ...string) map[string]any {
+	return mapify(e, fields)
+}
+
+func (e attrErrorX) Inner() ErrorX {
+	return e.ErrorX
+}
+
+func (e *attrErrorX) LayerFields() map[string]any {
+	return maps.Clone(e.attrs)
+}
+
+func (e *attrErrorX) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *attrErrorX) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *attrErrorX) MarshalText() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *attrErrorX) UnmarshalText(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *attrErrorX) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(e)
+}
+
+func (e *attrErrorX) UnmarshalBinary(data []byte) error {
+	return unmarshalInto(e, DecodeBinary, data)
+}
+
+func (e *attrErrorX) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+func (e *attrErrorX) LogValue() slog.Value {
+	return logValue(e)
+}
+
+func (e *attrErrorX) encodeLayer() jsonLayer {
+	return jsonLayer{Type: layerTypeAttrs, Fields: redactionOf(e).redactFields(e.attrs)}
+}
+
+// With adds the attributes given by args to err, which show up in Fields(),
+// the JSON encoding, LogValue, SlogHandler and the %+v verb. args are
+// key-value pairs and slog.Attr values, the way slog.Logger.Info takes them.
+// Errors that aren't ErrorX values are first wrapped in one.
+//
+// When several layers set the same key, the outermost one wins, so the
+// attributes given last take precedence. This holds for the fields of the
+// built-in layers too: a "message" or "status" attribute replaces that field
+// in Fields(), though SlogHandler, which builds those members itself, leaves
+// such attributes out.
+func With(err error, args ...any) ErrorX {
+	if err == nil {
+		return nil
+	}
+
+	ex, ok := err.(ErrorX)
+	if !ok {
+		ex = newf(err, nil, "")
+	}
+
+	return &attrErrorX{ErrorX: ex, attrs: argsToAttrs(args)}
+}
+
+// WithFields adds fields to err as attributes, the way With does.
+func WithFields(err error, fields map[string]any) ErrorX {
+	if err == nil {
+		return nil
+	}
+
+	ex, ok := err.(ErrorX)
+	if !ok {
+		ex = newf(err, nil, "")
+	}
+
+	return &attrErrorX{ErrorX: ex, attrs: maps.Clone(fields)}
+}
+
+func argsToAttrs(args []any) map[string]any {
+	attrs := make(map[string]any)
+	for len(args) != 0 {
+		switch a := args[0].(type) {
+		case slog.Attr:
+			attrs[a.Key] = a.Value.Resolve().Any()
+			args = args[1:]
+		case string:
+			if len(args) == 1 {
+				attrs[badKey] = a
+				return attrs
+			}
+			attrs[a] = args[1]
+			args = args[2:]
+		default:
+			attrs[badKey] = a
+			args = args[1:]
+		}
+	}
+
+	return attrs
+}
+
+// layerAttributes returns the attributes of the layers of e, without the
+// ones of its causes, the outermost winning.
+func layerAttributes(e ErrorX) map[string]any {
+	attrs := make(map[string]any)
+	for ex := e; ex != nil; ex = ex.Inner() {
+		if a, ok := ex.(Attributer); ok {
+			for k, v := range a.Attributes() {
+				if _, ok := attrs[k]; !ok {
+					attrs[k] = v
+				}
+			}
+		}
+	}
+
+	return attrs
+}
+
+// Attributes returns the attributes of every Attributer found in the chain
+// of err, including errors.Join branches. When several set the same key, the
+// outermost one wins.
+func Attributes(err error) map[string]any {
+	attrs := make(map[string]any)
+	walk(err, func(e error) bool {
+		if a, ok := e.(Attributer); ok {
+			for k, v := range a.Attributes() {
+				if _, ok := attrs[k]; !ok {
+					attrs[k] = v
+				}
+			}
+		}
+		return true
+	})
+
+	return attrs
+}
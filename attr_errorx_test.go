@@ -0,0 +1,166 @@
+package errorsx_test
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWith(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		args []any
+		want map[string]any
+	}{
+		{
+			name: "key-value pairs",
+			args: []any{"user_id", 42, "tenant", "acme"},
+			want: map[string]any{"user_id": 42, "tenant": "acme"},
+		},
+		{
+			name: "slog.Attr",
+			args: []any{slog.String("tenant", "acme"), "order_id", "o-1"},
+			want: map[string]any{"tenant": "acme", "order_id": "o-1"},
+		},
+		{
+			name: "missing value",
+			args: []any{"user_id", 42, "tenant"},
+			want: map[string]any{"user_id": 42, "!BADKEY": "tenant"},
+		},
+		{
+			name: "missing key",
+			args: []any{42},
+			want: map[string]any{"!BADKEY": 42},
+		},
+		{
+			name: "same key twice",
+			args: []any{"user_id", 1, "user_id", 2},
+			want: map[string]any{"user_id": 2},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			errX := errorsx.With(errorsx.New("foo"), tc.args...)
+
+			assert.Equal(t, tc.want, errorsx.Attributes(errX))
+			for k, v := range tc.want {
+				assert.Equal(t, v, errX.Fields(k)[k])
+			}
+		})
+	}
+}
+
+func TestWith_Error(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, errorsx.With(nil, "user_id", 42))
+		assert.Nil(t, errorsx.WithFields(nil, map[string]any{"user_id": 42}))
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		t.Parallel()
+		err := errors.New("foo")
+		rx := callerRX("foo")
+		errX := errorsx.With(err, "user_id", 42)
+
+		assert.Regexp(t, rx, errX.Error())
+		assert.ErrorIs(t, errX, err)
+		assert.Equal(t, 42, errX.Fields("user_id")["user_id"])
+	})
+
+	t.Run("keeps the layers", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewHTTP(http.StatusNotFound, "foo").With("user_id", 42)
+
+		assert.Regexp(t, callerRX("foo: status 404"), errX.Error())
+		status, ok := errorsx.HTTPStatus(errX)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, map[string]any{"status": http.StatusNotFound, "user_id": 42}, errX.Fields("status", "user_id"))
+	})
+
+	t.Run("custom layer", func(t *testing.T) {
+		t.Parallel()
+		errX := (&resourceErrorX{ErrorX: errorsx.New("foo"), resource: "user"}).With("user_id", 42)
+
+		assert.Equal(t, map[string]any{"resource": "user", "user_id": 42}, errX.Fields("resource", "user_id"))
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		t.Parallel()
+		err := errors.New("bar")
+		errX := errorsx.New("foo").With("user_id", 42).Wrap(err)
+
+		assert.ErrorIs(t, errX, err)
+		assert.Equal(t, 42, errX.Fields("user_id")["user_id"])
+	})
+}
+
+func TestWith_Precedence(t *testing.T) {
+	t.Parallel()
+	errX := errorsx.New("foo").With("user_id", 1, "tenant", "acme").With("user_id", 2)
+
+	assert.Equal(t, map[string]any{"user_id": 2, "tenant": "acme"}, errorsx.Attributes(errX))
+	assert.Equal(t, map[string]any{"user_id": 2, "tenant": "acme"}, errX.Fields("user_id", "tenant"))
+
+	cause := errorsx.New("bar").With("user_id", 3, "order_id", "o-1")
+	err := fmt.Errorf("baz: %w", errorsx.NewWithError(cause, "foo").With("user_id", 2))
+	assert.Equal(t, map[string]any{"user_id": 2, "order_id": "o-1"}, errorsx.Attributes(err))
+}
+
+func TestWithFields(t *testing.T) {
+	t.Parallel()
+	fields := map[string]any{"user_id": 42}
+	errX := errorsx.WithFields(errorsx.New("foo"), fields)
+	fields["user_id"] = 0
+
+	assert.Equal(t, map[string]any{"user_id": 42}, errorsx.Attributes(errX))
+}
+
+func TestWith_Output(t *testing.T) {
+	t.Parallel()
+	errX := errorsx.New("foo", errorsx.WithStackMode(errorsx.StackNone)).
+		With("user_id", 42, "order_id", "o-1", "token", errorsx.Sensitive("s3cr3t"))
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		data, err := errorsx.EncodeJSON(errX)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "s3cr3t")
+
+		got, err := errorsx.DecodeJSON(data)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"user_id":  float64(42),
+			"order_id": "o-1",
+			"token":    errorsx.RedactedValue,
+		}, errorsx.Attributes(got))
+	})
+
+	t.Run("LogValue", func(t *testing.T) {
+		t.Parallel()
+		var b strings.Builder
+		slog.New(slog.NewTextHandler(&b, nil)).Info("failed", "err", errX)
+
+		assert.Contains(t, b.String(), "err.user_id=42")
+		assert.Contains(t, b.String(), "err.token=[REDACTED]")
+	})
+
+	t.Run("%+v", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, "foo\norder_id=o-1 token=[REDACTED] user_id=42", fmt.Sprintf("%+v", errX))
+	})
+}
@@ -0,0 +1,87 @@
+package errorsx
+
+import "iter"
+
+// Chain returns an iterator over the layers of e, outermost first, ending
+// with the innermost one, which holds the caller, stack and cause of e.
+func Chain(e ErrorX) iter.Seq[Layer] {
+	return func(yield func(Layer) bool) {
+		for ex := e; ex != nil; ex = ex.Inner() {
+			if !yield(ex) {
+				return
+			}
+		}
+	}
+}
+
+// Node is an error of the cause graph Tree walks: an ErrorX layer or any
+// other error.
+type Node struct {
+	// Err is the error itself.
+	Err error
+	// Depth is the number of links between Err and the root of the walk.
+	Depth int
+	// Message is the layer message of an ErrorX layer, with the ErrorX values
+	// it wraps rendered without their caller, or else Error().
+	Message string
+	// Caller and Stack are the ones the innermost layer of an ErrorX
+	// captured, empty for its other layers and for other errors. Stack is
+	// filtered by the package-wide StackFilter, as in Layers.
+	Caller string
+	Stack  Stack
+	// Fields are the fields an ErrorX layer contributes to Fields(), with
+	// sensitive values hidden, nil for other errors.
+	Fields map[string]any
+}
+
+// Tree returns an iterator over the cause graph of err, depth-first from
+// err itself. The layers of an ErrorX follow each other, the innermost one
+// leading to its causes, and errors.Join branches and foreign wrappers such
+// as the ones of fmt.Errorf are followed through their Unwrap methods.
+func Tree(err error) iter.Seq[Node] {
+	return func(yield func(Node) bool) {
+		walkTree(err, 0, yield)
+	}
+}
+
+func walkTree(err error, depth int, yield func(Node) bool) bool {
+	if err == nil {
+		return true
+	}
+
+	if !yield(newNode(err, depth)) {
+		return false
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if !walkTree(e, depth+1, yield) {
+				return false
+			}
+		}
+	case interface{ Unwrap() error }:
+		return walkTree(u.Unwrap(), depth+1, yield)
+	}
+
+	return true
+}
+
+func newNode(err error, depth int) Node {
+	n := Node{Err: err, Depth: depth}
+
+	ex, ok := err.(ErrorX)
+	if !ok {
+		n.Message = err.Error()
+		return n
+	}
+
+	n.Message = innermostMessage(ex, true)
+	n.Fields = redactionOf(ex).redactFields(ex.LayerFields())
+	if ex.Inner() == nil {
+		n.Caller = ex.Caller()
+		n.Stack = ex.Stack().Filter(GetStackFilter())
+	}
+
+	return n
+}
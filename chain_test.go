@@ -0,0 +1,156 @@
+package errorsx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	errorsxmock "github.com/caioreix/errorsx/mocks"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	errX := errorsx.NewHTTPWithError(validator.New().Struct(User{}), http.StatusBadRequest, "foo")
+
+	var layers []errorsx.Layer
+	for l := range errorsx.Chain(errX) {
+		layers = append(layers, l)
+	}
+	require.Len(t, layers, 3)
+
+	assert.Equal(t, errX, layers[0])
+	assert.Implements(t, (*errorsx.HTTPStatuser)(nil), layers[0])
+	assert.Implements(t, (*errorsx.FieldErrorer)(nil), layers[1])
+	assert.Nil(t, layers[2].Inner())
+	assert.Equal(t, errX.Caller(), layers[2].(errorsx.ErrorX).Caller())
+
+	t.Run("stops early", func(t *testing.T) {
+		t.Parallel()
+		n := 0
+		for range errorsx.Chain(errX) {
+			n++
+			break
+		}
+		assert.Equal(t, 1, n)
+	})
+}
+
+func TestTree(t *testing.T) {
+	t.Parallel()
+	dbErr := errors.New("db")
+	timeout := errors.New("timeout")
+	cause := errorsx.NewWithError(dbErr, "bar", errorsx.WithStackMode(errorsx.StackNone))
+	errX := errorsx.NewHTTPWithError(fmt.Errorf("baz: %w", cause), http.StatusNotFound, "foo").Wrap(timeout)
+
+	var (
+		got   []string
+		nodes []errorsx.Node
+	)
+	for n := range errorsx.Tree(errX) {
+		got = append(got, fmt.Sprintf("%d %q", n.Depth, n.Message))
+		nodes = append(nodes, n)
+	}
+
+	assert.Equal(t, []string{
+		`0 "status 404"`,
+		`1 "foo: baz: bar: db\ntimeout"`,
+		`2 "baz: bar: db\ntimeout"`,
+		`3 "baz: bar: db"`,
+		`4 "bar: db"`,
+		`5 "db"`,
+		`3 "timeout"`,
+	}, got)
+	require.Len(t, nodes, 7)
+
+	assert.Equal(t, errX, nodes[0].Err)
+	assert.Equal(t, map[string]any{"status": http.StatusNotFound}, nodes[0].Fields)
+	assert.Empty(t, nodes[0].Caller)
+	assert.Empty(t, nodes[0].Stack)
+
+	assert.Equal(t, errX.Inner(), nodes[1].Err)
+	assert.Equal(t, errX.Caller(), nodes[1].Caller)
+	assert.Equal(t, errX.Stack().Filter(errorsx.GetStackFilter()), nodes[1].Stack)
+
+	assert.Nil(t, nodes[3].Fields)
+	assert.Equal(t, cause, nodes[4].Err)
+	assert.Equal(t, map[string]any{"message": "bar", "error": "db"}, nodes[4].Fields)
+	assert.Equal(t, dbErr, nodes[5].Err)
+	assert.Equal(t, timeout, nodes[6].Err)
+
+	t.Run("ErrorX cause", func(t *testing.T) {
+		t.Parallel()
+		wrapped := errorsx.NewHTTPWithError(errorsx.New("bar"), http.StatusNotFound, "foo")
+
+		var got []string
+		for n := range errorsx.Tree(wrapped) {
+			got = append(got, n.Message)
+		}
+		assert.Equal(t, []string{"status 404", "foo: bar", "bar"}, got)
+	})
+
+	t.Run("stops early", func(t *testing.T) {
+		t.Parallel()
+		n := 0
+		for range errorsx.Tree(errX) {
+			n++
+			if n == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, n)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		for range errorsx.Tree(nil) {
+			t.Fail()
+		}
+	})
+}
+
+func TestTree_Mock(t *testing.T) {
+	t.Parallel()
+	stack := errorsx.Stack{{Function: "main.main", File: "main.go", Line: 1}}
+
+	errX := errorsxmock.NewErrorX(t)
+	errX.EXPECT().Inner().Return(nil)
+	errX.EXPECT().LayerMessage().Return("foo")
+	errX.EXPECT().LayerFields().Return(map[string]any{"user_id": 42})
+	errX.EXPECT().Caller().Return("caller")
+	errX.EXPECT().Stack().Return(stack)
+	errX.EXPECT().Unwrap().Return(nil)
+
+	var nodes []errorsx.Node
+	for n := range errorsx.Tree(errX) {
+		nodes = append(nodes, n)
+	}
+
+	assert.Equal(t, []errorsx.Node{{
+		Err:     errX,
+		Message: "foo",
+		Caller:  "caller",
+		Stack:   stack,
+		Fields:  map[string]any{"user_id": 42},
+	}}, nodes)
+}
+
+func TestTree_StackFilter(t *testing.T) {
+	defer errorsx.SetStackFilter(errorsx.GetStackFilter())
+	errorsx.SetStackFilter(errorsx.StackFilter{DropPrefixes: []string{"testing."}})
+
+	errX := errorsx.New("foo")
+	for n := range errorsx.Tree(errX) {
+		assert.Equal(t, errX.Stack().Filter(errorsx.GetStackFilter()), n.Stack)
+		assert.Less(t, len(n.Stack), len(errX.Stack()))
+	}
+}
@@ -0,0 +1,208 @@
+package errorsx
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Code is a machine-readable error code, declared once with DefineCode along
+// with the defaults of the errors it creates. A *Code is also a sentinel:
+// errors.Is reports whether an error chain carries it.
+type Code struct {
+	// Code is the unique identifier, such as "USER_NOT_FOUND".
+	Code string
+	// Message is the default message of the errors created from the code.
+	Message string
+	// HTTPStatus, when not zero, is the status of the errors created from
+	// the code.
+	HTTPStatus int
+	// GRPCCode is the google.golang.org/grpc/codes value of the code.
+	GRPCCode uint32
+}
+
+var codes = struct {
+	sync.RWMutex
+	m map[string]*Code
+}{m: make(map[string]*Code)}
+
+// DefineCode registers c and returns it for use as a sentinel. It panics if
+// c.Code is empty or was already defined, so codes are meant to be defined
+// in package-level variables.
+func DefineCode(c Code) *Code {
+	if c.Code == "" {
+		panic("errorsx: empty error code")
+	}
+
+	codes.Lock()
+	defer codes.Unlock()
+
+	if _, ok := codes.m[c.Code]; ok {
+		panic("errorsx: error code " + c.Code + " defined twice")
+	}
+
+	codes.m[c.Code] = &c
+	return &c
+}
+
+// LookupCode returns the Code defined under code.
+func LookupCode(code string) (*Code, bool) {
+	codes.RLock()
+	defer codes.RUnlock()
+
+	c, ok := codes.m[code]
+	return c, ok
+}
+
+func (c *Code) Error() string {
+	return c.Message
+}
+
+// Is reports whether target carries the same code as c.
+func (c *Code) Is(target error) bool {
+	tc, ok := target.(ErrorCoder)
+	return ok && tc.ErrorCode().Code == c.Code
+}
+
+func (c *Code) ErrorCode() *Code {
+	return c
+}
+
+// New creates an error carrying c with its default message.
+func (c *Code) New(opts ...Option) ErrorX {
+	return c.wrap(newf(nil, opts, "%s", c.Message))
+}
+
+// Newf creates an error carrying c with a formatted message.
+func (c *Code) Newf(format string, args ...any) ErrorX {
+	return c.wrap(newf(nil, nil, format, args...))
+}
+
+// NewWithError creates an error carrying c with its default message that
+// wraps err.
+func (c *Code) NewWithError(err error, opts ...Option) ErrorX {
+	return c.wrap(newf(err, opts, "%s", c.Message))
+}
+
+func (c *Code) wrap(e ErrorX) ErrorX {
+	if c.HTTPStatus != 0 {
+		e = &httpErrorX{ErrorX: e, status: c.HTTPStatus}
+	}
+
+	return &codeErrorX{ErrorX: e, code: c}
+}
+
+// ErrorCoder is implemented by errors that carry a Code.
+type ErrorCoder interface {
+	ErrorCode() *Code
+}
+
+type codeErrorX struct {
+	ErrorX
+
+	code *Code
+}
+
+var (
+	_ ErrorX     = (*codeErrorX)(nil)
+	_ ErrorCoder = (*codeErrorX)(nil)
+	_ ErrorCoder = (*Code)(nil)
+)
+
+func (e *codeErrorX) Error() string {
+	return stringify(e)
+}
+
+func (e *codeErrorX) Unwrap() error {
+	return e.Inner()
+}
+
+func (e codeErrorX) Wrap(err error) ErrorX {
+	e.ErrorX = e.ErrorX.Wrap(err)
+	return &e
+}
+
+func (e *codeErrorX) With(args ...any) ErrorX {
+	return With(e, args...)
+}
+
+func (e *codeErrorX) ErrorCode() *Code {
+	return e.code
+}
+
+// Is reports whether target carries the same code as e.
+func (e *codeErrorX) Is(target error) bool {
+	return e.code.Is(target)
+}
+
+func (e *codeErrorX) LayerMessage() string {
+	return "code " + e.code.Code
+}
+
+func (e *codeErrorX) Fields(fields ...string) map[string]any {
+	return mapify(e, fields)
+}
+
+func (e codeErrorX) Inner() ErrorX {
+	return e.ErrorX
+}
+
+func (e *codeErrorX) LayerFields() map[string]any {
+	return map[string]any{"code": e.code.Code}
+}
+
+func (e *codeErrorX) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *codeErrorX) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *codeErrorX) MarshalText() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *codeErrorX) UnmarshalText(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *codeErrorX) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(e)
+}
+
+func (e *codeErrorX) UnmarshalBinary(data []byte) error {
+	return unmarshalInto(e, DecodeBinary, data)
+}
+
+func (e *codeErrorX) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+func (e *codeErrorX) LogValue() slog.Value {
+	return logValue(e)
+}
+
+func (e *codeErrorX) encodeLayer() jsonLayer {
+	return jsonLayer{Type: layerTypeCode, Code: e.code.Code}
+}
+
+// ErrorCode returns the Code of the outermost ErrorCoder found in the chain
+// of err, including errors.Join branches. ErrorCoders returning nil are
+// skipped.
+func ErrorCode(err error) (*Code, bool) {
+	var (
+		code  *Code
+		found bool
+	)
+
+	walk(err, func(e error) bool {
+		if ec, ok := e.(ErrorCoder); ok && ec.ErrorCode() != nil {
+			code, found = ec.ErrorCode(), true
+			return false
+		}
+		return true
+	})
+
+	return code, found
+}
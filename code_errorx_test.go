@@ -0,0 +1,171 @@
+package errorsx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errUserNotFound = errorsx.DefineCode(errorsx.Code{
+		Code:       "USER_NOT_FOUND",
+		Message:    "user not found",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   5,
+	})
+	errUserBlocked = errorsx.DefineCode(errorsx.Code{
+		Code:    "USER_BLOCKED",
+		Message: "user blocked",
+	})
+)
+
+func TestDefineCode(t *testing.T) {
+	t.Parallel()
+
+	got, ok := errorsx.LookupCode("USER_NOT_FOUND")
+	require.True(t, ok)
+	assert.Same(t, errUserNotFound, got)
+
+	assert.PanicsWithValue(t, "errorsx: error code USER_NOT_FOUND defined twice", func() {
+		errorsx.DefineCode(errorsx.Code{Code: "USER_NOT_FOUND"})
+	})
+	assert.PanicsWithValue(t, "errorsx: empty error code", func() {
+		errorsx.DefineCode(errorsx.Code{})
+	})
+}
+
+func TestCodeErrorX_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with HTTP status", func(t *testing.T) {
+		t.Parallel()
+		rx := callerRX(fmt.Sprintf("user not found: code USER_NOT_FOUND: status %d", http.StatusNotFound))
+		errX := errUserNotFound.New()
+		assert.Regexp(t, rx, errX.Error())
+
+		status, ok := errorsx.HTTPStatus(errX)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("without HTTP status", func(t *testing.T) {
+		t.Parallel()
+		rx := callerRX("user 42 blocked: code USER_BLOCKED")
+		errX := errUserBlocked.Newf("user %d blocked", 42)
+		assert.Regexp(t, rx, errX.Error())
+
+		_, ok := errorsx.HTTPStatus(errX)
+		assert.False(t, ok)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		t.Parallel()
+		err := fmt.Errorf("fake error")
+		rx := callerRX(fmt.Sprintf("user blocked: %s: code USER_BLOCKED", err.Error()))
+		errX := errUserBlocked.NewWithError(err)
+		assert.Regexp(t, rx, errX.Error())
+		assert.ErrorIs(t, errX, err)
+	})
+}
+
+func TestCodeErrorX_Fields(t *testing.T) {
+	t.Parallel()
+	want := map[string]any{
+		"message": "user not found",
+		"status":  http.StatusNotFound,
+		"code":    "USER_NOT_FOUND",
+	}
+
+	got := errUserNotFound.New().Fields("message", "status", "code")
+	assert.Equal(t, want, got)
+}
+
+func TestCodeErrorX_Is(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "same code",
+			err:    errUserNotFound.New(),
+			target: errUserNotFound,
+			want:   true,
+		},
+		{
+			name:   "other code",
+			err:    errUserNotFound.New(),
+			target: errUserBlocked,
+			want:   false,
+		},
+		{
+			name:   "against another error with the same code",
+			err:    errUserNotFound.New(),
+			target: errUserNotFound.Newf("user %d not found", 42),
+			want:   true,
+		},
+		{
+			name:   "wrapped by Wrap",
+			err:    errorsx.New("foo").Wrap(errors.New("bar")).Wrap(errUserNotFound.New()),
+			target: errUserNotFound,
+			want:   true,
+		},
+		{
+			name:   "wrapping with Wrap",
+			err:    errUserNotFound.New().Wrap(errors.New("bar")),
+			target: errUserNotFound,
+			want:   true,
+		},
+		{
+			name:   "wrapped by HTTPErrorX and fmt.Errorf",
+			err:    fmt.Errorf("baz: %w", errorsx.NewHTTPWithError(errUserNotFound.New(), http.StatusBadGateway, "foo")),
+			target: errUserNotFound,
+			want:   true,
+		},
+		{
+			name:   "without code",
+			err:    errorsx.New("foo"),
+			target: errUserNotFound,
+			want:   false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, errorsx.Is(tc.err, tc.target))
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	t.Parallel()
+
+	got, ok := errorsx.ErrorCode(errorsx.New("foo").Wrap(errUserBlocked.New()))
+	assert.True(t, ok)
+	assert.Same(t, errUserBlocked, got)
+
+	_, ok = errorsx.ErrorCode(errorsx.New("foo"))
+	assert.False(t, ok)
+}
+
+func TestCodeErrorX_DecodeJSON(t *testing.T) {
+	t.Parallel()
+	want := errUserNotFound.New()
+
+	data, err := errorsx.EncodeJSON(want)
+	require.NoError(t, err)
+
+	got, err := errorsx.DecodeJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, want.Error(), got.Error())
+	assert.ErrorIs(t, got, errUserNotFound)
+}
@@ -0,0 +1,116 @@
+package errorsx
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// ContextExtractor returns the fields to copy from ctx into the errors
+// created with it, such as the request ID set by a middleware.
+type ContextExtractor func(ctx context.Context) map[string]any
+
+type namedExtractor struct {
+	name string
+	fn   ContextExtractor
+}
+
+var contextExtractors struct {
+	sync.RWMutex
+	list []namedExtractor
+}
+
+// RegisterContextExtractor registers fn under name, replacing the extractor
+// already registered under that name. Extractors run in registration order,
+// a later one winning when two return the same key. None is registered by
+// default; otelx.RegisterTraceExtractor registers the one copying the
+// OpenTelemetry trace and span IDs.
+func RegisterContextExtractor(name string, fn ContextExtractor) {
+	contextExtractors.Lock()
+	defer contextExtractors.Unlock()
+
+	for i, e := range contextExtractors.list {
+		if e.name == name {
+			contextExtractors.list[i].fn = fn
+			return
+		}
+	}
+
+	contextExtractors.list = append(contextExtractors.list, namedExtractor{name: name, fn: fn})
+}
+
+// UnregisterContextExtractor removes the extractor registered under name.
+func UnregisterContextExtractor(name string) {
+	contextExtractors.Lock()
+	defer contextExtractors.Unlock()
+
+	contextExtractors.list = slices.DeleteFunc(contextExtractors.list, func(e namedExtractor) bool {
+		return e.name == name
+	})
+}
+
+// ContextValue returns a ContextExtractor copying the value ctx carries
+// under key into field, when there is one.
+func ContextValue(field string, key any) ContextExtractor {
+	return func(ctx context.Context) map[string]any {
+		v := ctx.Value(key)
+		if v == nil {
+			return nil
+		}
+
+		return map[string]any{field: v}
+	}
+}
+
+// contextFields runs every registered extractor on ctx.
+func contextFields(ctx context.Context) map[string]any {
+	contextExtractors.RLock()
+	defer contextExtractors.RUnlock()
+
+	fields := make(map[string]any)
+	for _, e := range contextExtractors.list {
+		for k, v := range e.fn(ctx) {
+			fields[k] = v
+		}
+	}
+
+	return fields
+}
+
+// WithContext makes the error carry, as attributes, the fields the
+// registered extractors copy from ctx when the error is created.
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.contextFields = contextFields(ctx)
+	}
+}
+
+// NewCtx creates an error the way New does, carrying the fields copied
+// from ctx and with its validation failures translated in the locale of
+// ctx, as WithContext and WithContextLocale do.
+func NewCtx(ctx context.Context, message string, opts ...Option) ErrorX {
+	return newf(nil, ctxOptions(ctx, opts), "%s", message)
+}
+
+// NewCtxf creates an error the way Newf does, with ctx as NewCtx. Like Newf,
+// it takes no Option.
+func NewCtxf(ctx context.Context, format string, args ...any) ErrorX {
+	return newf(nil, ctxOptions(ctx, nil), format, args...)
+}
+
+// NewCtxWithError creates an error the way NewWithError does, with ctx as
+// NewCtx.
+func NewCtxWithError(ctx context.Context, err error, message string, opts ...Option) ErrorX {
+	return newf(err, ctxOptions(ctx, opts), "%s", message)
+}
+
+// NewCtxWithErrorf creates an error the way NewWithErrorf does, with ctx as
+// NewCtx.
+func NewCtxWithErrorf(ctx context.Context, err error, format string, args ...any) ErrorX {
+	return newf(err, ctxOptions(ctx, nil), format, args...)
+}
+
+// ctxOptions puts the options of ctx before opts, which can override them.
+func ctxOptions(ctx context.Context, opts []Option) []Option {
+	return append([]Option{WithContext(ctx), WithContextLocale(ctx)}, opts...)
+}
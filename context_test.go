@@ -0,0 +1,115 @@
+package errorsx_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/stretchr/testify/assert"
+)
+
+type requestIDKey struct{}
+
+func TestContextValue(t *testing.T) {
+	t.Parallel()
+	extract := errorsx.ContextValue("request_id", requestIDKey{})
+
+	assert.Nil(t, extract(t.Context()))
+
+	ctx := context.WithValue(t.Context(), requestIDKey{}, "r-1")
+	assert.Equal(t, map[string]any{"request_id": "r-1"}, extract(ctx))
+}
+
+func TestNewCtx(t *testing.T) {
+	defer errorsx.UnregisterContextExtractor("request")
+	errorsx.RegisterContextExtractor("request", errorsx.ContextValue("request_id", requestIDKey{}))
+
+	ctx := context.WithValue(t.Context(), requestIDKey{}, "r-1")
+	want := map[string]any{"request_id": "r-1"}
+	cause := errors.New("bar")
+
+	tt := []struct {
+		name    string
+		errX    errorsx.ErrorX
+		rx      string
+		wantErr error
+	}{
+		{
+			name: "NewCtx",
+			errX: errorsx.NewCtx(ctx, "foo"),
+			rx:   callerRX("foo"),
+		},
+		{
+			name: "NewCtxf",
+			errX: errorsx.NewCtxf(ctx, "foo %d", 1),
+			rx:   callerRX("foo 1"),
+		},
+		{
+			name:    "NewCtxWithError",
+			errX:    errorsx.NewCtxWithError(ctx, cause, "foo"),
+			rx:      callerRX("foo: bar"),
+			wantErr: cause,
+		},
+		{
+			name:    "NewCtxWithErrorf",
+			errX:    errorsx.NewCtxWithErrorf(ctx, cause, "foo %d", 1),
+			rx:      callerRX("foo 1: bar"),
+			wantErr: cause,
+		},
+		{
+			name: "WithContext",
+			errX: errorsx.NewHTTP(http.StatusNotFound, "foo", errorsx.WithContext(ctx)),
+			rx:   callerRX("foo: status 404"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Regexp(t, tc.rx, tc.errX.Error())
+			assert.Equal(t, want, errorsx.Attributes(tc.errX))
+			assert.Equal(t, want, tc.errX.Fields("request_id"))
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.errX, tc.wantErr)
+			}
+		})
+	}
+
+	t.Run("empty context", func(t *testing.T) {
+		errX := errorsx.NewCtx(t.Context(), "foo")
+		assert.Empty(t, errorsx.Attributes(errX))
+	})
+
+	t.Run("With overrides", func(t *testing.T) {
+		errX := errorsx.NewCtx(ctx, "foo").With("request_id", "override")
+		assert.Equal(t, "override", errX.Fields("request_id")["request_id"])
+	})
+}
+
+func TestNewCtx_NoExtractor(t *testing.T) {
+	t.Parallel()
+	ctx := context.WithValue(t.Context(), requestIDKey{}, "r-1")
+	assert.Empty(t, errorsx.Attributes(errorsx.NewCtx(ctx, "foo")))
+}
+
+func TestRegisterContextExtractor(t *testing.T) {
+	defer errorsx.UnregisterContextExtractor("request")
+	defer errorsx.UnregisterContextExtractor("user")
+
+	errorsx.RegisterContextExtractor("request", errorsx.ContextValue("request_id", requestIDKey{}))
+	errorsx.RegisterContextExtractor("user", func(context.Context) map[string]any {
+		return map[string]any{"user_id": 42, "request_id": "overridden"}
+	})
+
+	ctx := context.WithValue(t.Context(), requestIDKey{}, "r-1")
+	assert.Equal(t, map[string]any{"request_id": "overridden", "user_id": 42}, errorsx.Attributes(errorsx.NewCtx(ctx, "foo")))
+
+	errorsx.RegisterContextExtractor("user", func(context.Context) map[string]any {
+		return map[string]any{"user_id": 7}
+	})
+	assert.Equal(t, map[string]any{"request_id": "r-1", "user_id": 7}, errorsx.Attributes(errorsx.NewCtx(ctx, "foo")))
+
+	errorsx.UnregisterContextExtractor("user")
+	assert.Equal(t, map[string]any{"request_id": "r-1"}, errorsx.Attributes(errorsx.NewCtx(ctx, "foo")))
+}
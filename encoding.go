@@ -0,0 +1,478 @@
+package errorsx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	layerTypeError      = "error"
+	layerTypeHTTP       = "http"
+	layerTypeValidation = "validation"
+	layerTypeCode       = "code"
+	layerTypeKind       = "kind"
+	layerTypeAttrs      = "attrs"
+	layerTypeCustom     = "custom"
+
+	binaryVersion byte = 1
+)
+
+// ErrInvalidEncoding is returned when decoding data that wasn't produced by
+// EncodeJSON or EncodeBinary.
+var ErrInvalidEncoding = errors.New("errorsx: invalid encoding")
+
+// jsonNode is the wire form of an error. ErrorX values are encoded as their
+// layers, outermost first; any other error is encoded as its message and the
+// errors it unwraps to.
+type jsonNode struct {
+	Layers  []jsonLayer `json:"layers,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Causes  []jsonNode  `json:"causes,omitempty"`
+}
+
+type jsonLayer struct {
+	Type             string           `json:"type"`
+	Message          string           `json:"message,omitempty"`
+	PublicMessage    string           `json:"public_message,omitempty"`
+	Caller           string           `json:"caller,omitempty"`
+	Stack            Stack            `json:"stack,omitempty"`
+	Cause            *jsonNode        `json:"cause,omitempty"`
+	Status           int              `json:"status,omitempty"`
+	Code             string           `json:"code,omitempty"`
+	Kind             string           `json:"kind,omitempty"`
+	ValidationErrors []jsonFieldError `json:"validation_errors,omitempty"`
+	Fields           map[string]any   `json:"fields,omitempty"`
+}
+
+type jsonFieldError struct {
+	Namespace       string          `json:"namespace"`
+	StructNamespace string          `json:"struct_namespace,omitempty"`
+	Field           string          `json:"field"`
+	StructField     string          `json:"struct_field,omitempty"`
+	Tag             string          `json:"tag"`
+	ActualTag       string          `json:"actual_tag,omitempty"`
+	Param           string          `json:"param,omitempty"`
+	Kind            string          `json:"kind,omitempty"`
+	Value           json.RawMessage `json:"value,omitempty"`
+	Error           string          `json:"error"`
+	// Rootless marks failures whose namespace has no top-level struct, such
+	// as the ones of ValidationBuilder.
+	Rootless bool `json:"rootless,omitempty"`
+}
+
+// layerEncoder is implemented by the built-in layers to describe themselves
+// on the wire. Layers that don't implement it are encoded as custom layers
+// from their LayerMessage and LayerFields.
+type layerEncoder interface {
+	encodeLayer() jsonLayer
+}
+
+type layerDecoder func(l jsonLayer, inner ErrorX) (ErrorX, error)
+
+var layerDecoders = map[string]layerDecoder{
+	layerTypeHTTP:       decodeHTTPLayer,
+	layerTypeValidation: decodeValidationLayer,
+	layerTypeCode:       decodeCodeLayer,
+	layerTypeKind:       decodeKindLayer,
+	layerTypeAttrs:      decodeAttrsLayer,
+	layerTypeCustom:     decodeCustomLayer,
+}
+
+// EncodeJSON encodes the whole chain of e: every layer with its message,
+// caller, stack, cause chain, status and validation errors. It backs the
+// MarshalJSON and MarshalText methods of the built-in layers; MarshalText
+// produces the same bytes.
+func EncodeJSON(e ErrorX) ([]byte, error) {
+	return json.Marshal(encodeErrorX(e))
+}
+
+// DecodeJSON reconstructs an ErrorX from the output of EncodeJSON. Causes
+// that weren't ErrorX values come back as plain errors with the same message
+// and unwrap chain.
+func DecodeJSON(data []byte) (ErrorX, error) {
+	var n jsonNode
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, err
+	}
+
+	return decodeErrorX(n)
+}
+
+// EncodeBinary encodes e the way EncodeJSON does, prefixed by a format
+// version byte. It backs the MarshalBinary methods of the built-in layers.
+func EncodeBinary(e ErrorX) ([]byte, error) {
+	data, err := EncodeJSON(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{binaryVersion}, data...), nil
+}
+
+// DecodeBinary reconstructs an ErrorX from the output of EncodeBinary.
+func DecodeBinary(data []byte) (ErrorX, error) {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return nil, fmt.Errorf("%w: unsupported binary version", ErrInvalidEncoding)
+	}
+
+	return DecodeJSON(data[1:])
+}
+
+// unmarshalInto decodes data and copies the result into dst, which must be
+// a pointer to the same layer type as the outermost decoded layer.
+func unmarshalInto[T any](dst *T, decode func([]byte) (ErrorX, error), data []byte) error {
+	e, err := decode(data)
+	if err != nil {
+		return err
+	}
+
+	src, ok := any(e).(*T)
+	if !ok {
+		return fmt.Errorf("%w: cannot decode %T into %T", ErrInvalidEncoding, e, dst)
+	}
+
+	*dst = *src
+	return nil
+}
+
+func encodeErrorX(e ErrorX) jsonNode {
+	var n jsonNode
+	p := redactionOf(e)
+	for ex := e; ex != nil; ex = ex.Inner() {
+		if le, ok := ex.(layerEncoder); ok {
+			n.Layers = append(n.Layers, le.encodeLayer())
+			continue
+		}
+
+		n.Layers = append(n.Layers, jsonLayer{
+			Type:    layerTypeCustom,
+			Message: ex.LayerMessage(),
+			Fields:  p.redactFields(ex.LayerFields()),
+		})
+	}
+
+	return n
+}
+
+func encodeError(err error) jsonNode {
+	if ex, ok := err.(ErrorX); ok {
+		return encodeErrorX(ex)
+	}
+
+	n := jsonNode{Message: err.Error()}
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if e != nil {
+				n.Causes = append(n.Causes, encodeError(e))
+			}
+		}
+	case interface{ Unwrap() error }:
+		if e := u.Unwrap(); e != nil {
+			n.Causes = append(n.Causes, encodeError(e))
+		}
+	}
+
+	return n
+}
+
+func decodeErrorX(n jsonNode) (ErrorX, error) {
+	if len(n.Layers) == 0 {
+		return nil, fmt.Errorf("%w: no layers", ErrInvalidEncoding)
+	}
+
+	last := n.Layers[len(n.Layers)-1]
+	if last.Type != layerTypeError {
+		return nil, fmt.Errorf("%w: innermost layer is %q", ErrInvalidEncoding, last.Type)
+	}
+
+	ex, err := decodeErrorLayer(last)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(n.Layers) - 2; i >= 0; i-- {
+		decode, ok := layerDecoders[n.Layers[i].Type]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown layer type %q", ErrInvalidEncoding, n.Layers[i].Type)
+		}
+
+		ex, err = decode(n.Layers[i], ex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ex, nil
+}
+
+func decodeError(n jsonNode) (error, error) {
+	if len(n.Layers) != 0 {
+		return decodeErrorX(n)
+	}
+
+	de := &decodedError{message: n.Message}
+	for _, c := range n.Causes {
+		err, derr := decodeError(c)
+		if derr != nil {
+			return nil, derr
+		}
+		de.causes = append(de.causes, err)
+	}
+
+	return de, nil
+}
+
+func decodeErrorLayer(l jsonLayer) (ErrorX, error) {
+	e := &errorX{
+		message:    l.Message,
+		public:     l.PublicMessage,
+		callers:    resolvedCallers(l.Caller, l.Stack),
+		validation: options{}.validation(),
+	}
+
+	if l.Cause != nil {
+		err, derr := decodeError(*l.Cause)
+		if derr != nil {
+			return nil, derr
+		}
+		e.err = err
+	}
+
+	return e, nil
+}
+
+func decodeHTTPLayer(l jsonLayer, inner ErrorX) (ErrorX, error) {
+	return &httpErrorX{ErrorX: inner, status: l.Status}, nil
+}
+
+func decodeValidationLayer(l jsonLayer, inner ErrorX) (ErrorX, error) {
+	e := &validationErrorX{ErrorX: inner, validation: options{}.validation()}
+	for _, fe := range l.ValidationErrors {
+		if fe.Rootless {
+			e.fieldErrors = append(e.fieldErrors, newDecodedViolation(fe))
+			continue
+		}
+		e.fieldErrors = append(e.fieldErrors, newDecodedFieldError(fe))
+	}
+
+	return e, nil
+}
+
+// decodeCodeLayer restores the Code defined under the decoded identifier,
+// falling back to a bare Code when it isn't defined on this side.
+func decodeCodeLayer(l jsonLayer, inner ErrorX) (ErrorX, error) {
+	c, ok := LookupCode(l.Code)
+	if !ok {
+		c = &Code{Code: l.Code}
+	}
+
+	return &codeErrorX{ErrorX: inner, code: c}, nil
+}
+
+func decodeKindLayer(l jsonLayer, inner ErrorX) (ErrorX, error) {
+	k, ok := ParseKind(l.Kind)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidEncoding, l.Kind)
+	}
+
+	return &kindErrorX{ErrorX: inner, kind: k}, nil
+}
+
+func decodeAttrsLayer(l jsonLayer, inner ErrorX) (ErrorX, error) {
+	return &attrErrorX{ErrorX: inner, attrs: l.Fields}, nil
+}
+
+func decodeCustomLayer(l jsonLayer, inner ErrorX) (ErrorX, error) {
+	return &customErrorX{ErrorX: inner, message: l.Message, fields: l.Fields}, nil
+}
+
+func encodeFieldError(fe validator.FieldError) jsonFieldError {
+	jfe := jsonFieldError{
+		Namespace:       fe.Namespace(),
+		StructNamespace: fe.StructNamespace(),
+		Field:           fe.Field(),
+		StructField:     fe.StructField(),
+		Tag:             fe.Tag(),
+		ActualTag:       fe.ActualTag(),
+		Param:           fe.Param(),
+		Error:           fe.Error(),
+	}
+
+	if _, ok := fe.(interface{ Path() []string }); ok {
+		jfe.Rootless = true
+	}
+
+	if k := fe.Kind(); k != reflect.Invalid {
+		jfe.Kind = k.String()
+	}
+
+	if v, err := json.Marshal(fe.Value()); err == nil && string(v) != "null" {
+		jfe.Value = v
+	}
+
+	return jfe
+}
+
+// decodedError stands in for a foreign error after decoding, keeping its
+// message and the errors it wrapped.
+type decodedError struct {
+	message string
+	causes  []error
+}
+
+func (e *decodedError) Error() string {
+	return e.message
+}
+
+func (e *decodedError) Unwrap() []error {
+	return e.causes
+}
+
+// customErrorX stands in for a layer of a type unknown to this package after
+// decoding, keeping its message and fields.
+type customErrorX struct {
+	ErrorX
+
+	message string
+	fields  map[string]any
+}
+
+func (e *customErrorX) Error() string {
+	return stringify(e)
+}
+
+func (e *customErrorX) Unwrap() error {
+	return e.Inner()
+}
+
+func (e customErrorX) Wrap(err error) ErrorX {
+	e.ErrorX = e.ErrorX.Wrap(err)
+	return &e
+}
+
+func (e *customErrorX) With(args ...any) ErrorX {
+	return With(e, args...)
+}
+
+func (e *customErrorX) Fields(fields ...string) map[string]any {
+	return mapify(e, fields)
+}
+
+func (e *customErrorX) LayerMessage() string {
+	return e.message
+}
+
+func (e *customErrorX) LayerFields() map[string]any {
+	return e.fields
+}
+
+func (e customErrorX) Inner() ErrorX {
+	return e.ErrorX
+}
+
+func (e *customErrorX) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *customErrorX) MarshalText() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *customErrorX) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(e)
+}
+
+func (e *customErrorX) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+func (e *customErrorX) LogValue() slog.Value {
+	return logValue(e)
+}
+
+// decodedFieldError implements validator.FieldError for validation errors
+// rebuilt from their wire form.
+type decodedFieldError struct {
+	namespace       string
+	structNamespace string
+	field           string
+	structField     string
+	tag             string
+	actualTag       string
+	param           string
+	kind            reflect.Kind
+	value           any
+	message         string
+}
+
+var _ validator.FieldError = (*decodedFieldError)(nil)
+
+func newDecodedFieldError(jfe jsonFieldError) *decodedFieldError {
+	fe := &decodedFieldError{
+		namespace:       jfe.Namespace,
+		structNamespace: jfe.StructNamespace,
+		field:           jfe.Field,
+		structField:     jfe.StructField,
+		tag:             jfe.Tag,
+		actualTag:       jfe.ActualTag,
+		param:           jfe.Param,
+		message:         jfe.Error,
+	}
+
+	for k := reflect.Invalid; k <= reflect.UnsafePointer; k++ {
+		if k.String() == jfe.Kind {
+			fe.kind = k
+			break
+		}
+	}
+
+	if len(jfe.Value) != 0 {
+		_ = json.Unmarshal(jfe.Value, &fe.value)
+	}
+
+	return fe
+}
+
+// newDecodedViolation rebuilds a failure without a top-level struct as the
+// violation it was encoded from, keeping its path whole.
+func newDecodedViolation(jfe jsonFieldError) *violation {
+	v := FieldViolation{Path: jfe.Namespace, Rule: jfe.Tag, Message: jfe.Error, Params: strings.Fields(jfe.Param)}
+	if len(jfe.Value) != 0 {
+		_ = json.Unmarshal(jfe.Value, &v.Value)
+	}
+
+	return &violation{v: v, path: splitNamespace(v.Path)}
+}
+
+func (fe *decodedFieldError) Tag() string             { return fe.tag }
+func (fe *decodedFieldError) ActualTag() string       { return fe.actualTag }
+func (fe *decodedFieldError) Namespace() string       { return fe.namespace }
+func (fe *decodedFieldError) StructNamespace() string { return fe.structNamespace }
+func (fe *decodedFieldError) Field() string           { return fe.field }
+func (fe *decodedFieldError) StructField() string     { return fe.structField }
+func (fe *decodedFieldError) Value() any              { return fe.value }
+func (fe *decodedFieldError) Param() string           { return fe.param }
+func (fe *decodedFieldError) Kind() reflect.Kind      { return fe.kind }
+func (fe *decodedFieldError) Type() reflect.Type      { return nil }
+func (fe *decodedFieldError) Error() string           { return fe.message }
+
+func (fe *decodedFieldError) Translate(trans ut.Translator) string {
+	if trans == nil {
+		return fe.message
+	}
+
+	msg, err := trans.T(fe.tag, fe.field, fe.param)
+	if err != nil {
+		return fe.message
+	}
+
+	return msg
+}
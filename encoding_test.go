@@ -0,0 +1,236 @@
+package errorsx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name  string `validate:"required"`
+		Email string `validate:"required,email"`
+	}
+
+	validationErr := validator.New().Struct(User{Email: "x"})
+
+	tt := []struct {
+		name string
+		errX errorsx.ErrorX
+	}{
+		{
+			name: "ErrorX",
+			errX: errorsx.New("foo"),
+		},
+		{
+			name: "ErrorX with cause chain",
+			errX: errorsx.NewWithError(fmt.Errorf("bar: %w", errors.New("baz")), "foo"),
+		},
+		{
+			name: "ErrorX with joined causes",
+			errX: errorsx.New("foo").Wrap(errors.New("bar")).Wrap(errors.New("baz")),
+		},
+		{
+			name: "ErrorX with ErrorX cause",
+			errX: errorsx.NewWithError(errorsx.NewHTTP(http.StatusNotFound, "bar"), "foo"),
+		},
+		{
+			name: "HTTPErrorX",
+			errX: errorsx.NewHTTPWithError(errors.New("bar"), http.StatusConflict, "foo"),
+		},
+		{
+			name: "HTTPErrorX with ValidationErrorX",
+			errX: errorsx.NewHTTPWithError(validationErr, http.StatusBadRequest, "foo"),
+		},
+		{
+			name: "custom layer",
+			errX: &resourceErrorX{ErrorX: errorsx.New("foo"), resource: "user"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			data, err := errorsx.EncodeJSON(tc.errX)
+			require.NoError(t, err)
+
+			got, err := errorsx.DecodeJSON(data)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.errX.Error(), got.Error())
+			assert.Equal(t, tc.errX.Caller(), got.Caller())
+			assert.Equal(t, tc.errX.Stack(), got.Stack())
+			assert.Equal(t, tc.errX.Fields("message", "error", "status"), got.Fields("message", "error", "status"))
+
+			wantStatus, wantOK := errorsx.HTTPStatus(tc.errX)
+			gotStatus, gotOK := errorsx.HTTPStatus(got)
+			assert.Equal(t, wantStatus, gotStatus)
+			assert.Equal(t, wantOK, gotOK)
+		})
+	}
+}
+
+func TestErrorX_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	var (
+		msg    = "foo"
+		status = http.StatusNotFound
+	)
+
+	errX := errorsx.NewHTTP(status, msg)
+	data, err := json.Marshal(errX)
+	require.NoError(t, err)
+
+	var got struct {
+		Layers []map[string]any `json:"layers"`
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Len(t, got.Layers, 2)
+	assert.Equal(t, "http", got.Layers[0]["type"])
+	assert.EqualValues(t, status, got.Layers[0]["status"])
+	assert.Equal(t, "error", got.Layers[1]["type"])
+	assert.Equal(t, msg, got.Layers[1]["message"])
+	assert.Equal(t, errX.Caller(), got.Layers[1]["caller"])
+	assert.NotEmpty(t, got.Layers[1]["stack"])
+}
+
+func TestErrorX_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("same outermost layer", func(t *testing.T) {
+		t.Parallel()
+		want := errorsx.NewHTTPWithError(errors.New("bar"), http.StatusConflict, "foo")
+		data, err := json.Marshal(want)
+		require.NoError(t, err)
+
+		got := errorsx.NewHTTP(http.StatusOK, "")
+		require.NoError(t, json.Unmarshal(data, got))
+		assert.Equal(t, want.Error(), got.Error())
+	})
+
+	t.Run("different outermost layer", func(t *testing.T) {
+		t.Parallel()
+		data, err := json.Marshal(errorsx.NewHTTP(http.StatusConflict, "foo"))
+		require.NoError(t, err)
+
+		got := errorsx.New("")
+		err = json.Unmarshal(data, got)
+		assert.ErrorIs(t, err, errorsx.ErrInvalidEncoding)
+	})
+}
+
+func TestValidationErrorX_DecodeJSON(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name  string `validate:"required"`
+		Email string `validate:"required,email"`
+	}
+
+	validationErr := validator.New().Struct(User{Email: "x"})
+	want := errorsx.NewWithError(validationErr, "foo")
+
+	data, err := errorsx.EncodeJSON(want)
+	require.NoError(t, err)
+
+	got, err := errorsx.DecodeJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, want.Fields("validation_errors"), got.Fields("validation_errors"))
+
+	wantFieldErrs, _ := errorsx.FieldErrors(want)
+	gotFieldErrs, ok := errorsx.FieldErrors(got)
+	require.True(t, ok)
+	require.Len(t, gotFieldErrs, len(wantFieldErrs))
+	for i := range wantFieldErrs {
+		assert.Equal(t, wantFieldErrs[i].Namespace(), gotFieldErrs[i].Namespace())
+		assert.Equal(t, wantFieldErrs[i].Tag(), gotFieldErrs[i].Tag())
+		assert.Equal(t, wantFieldErrs[i].Param(), gotFieldErrs[i].Param())
+		assert.Equal(t, wantFieldErrs[i].Kind(), gotFieldErrs[i].Kind())
+		assert.Equal(t, wantFieldErrs[i].Value(), gotFieldErrs[i].Value())
+		assert.Equal(t, wantFieldErrs[i].Error(), gotFieldErrs[i].Error())
+	}
+}
+
+func TestValidationBuilder_Decode(t *testing.T) {
+	t.Parallel()
+	violation := errorsx.FieldViolation{
+		Path:    "items[0].name",
+		Rule:    "oneof",
+		Message: "name must be one of a b",
+		Params:  []string{"a", "b"},
+		Value:   "c",
+	}
+	want := errorsx.NewWithError(errorsx.NewValidation().AddViolation(violation).Err(), "foo")
+
+	jsonData, err := errorsx.EncodeJSON(want)
+	require.NoError(t, err)
+	fromJSON, err := errorsx.DecodeJSON(jsonData)
+	require.NoError(t, err)
+
+	binData, err := want.(interface{ MarshalBinary() ([]byte, error) }).MarshalBinary()
+	require.NoError(t, err)
+	fromBinary, err := errorsx.DecodeBinary(binData)
+	require.NoError(t, err)
+
+	for name, got := range map[string]errorsx.ErrorX{"JSON": fromJSON, "binary": fromBinary} {
+		assert.Equal(t, []errorsx.FieldViolation{violation}, errorsx.Violations(got), name)
+		assert.Equal(t, want.Fields("validation_errors"), got.Fields("validation_errors"), name)
+
+		fieldErrs, ok := errorsx.FieldErrors(got)
+		require.True(t, ok, name)
+		assert.Equal(t, "items[0].name", errorsx.FieldPath(fieldErrs[0]), name)
+		assert.Equal(t, "items[0].name", errorsx.NewProblem(got, nil).Errors[0].Field, name)
+	}
+}
+
+func TestEncodeBinary(t *testing.T) {
+	t.Parallel()
+	want := errorsx.NewHTTPWithError(errors.New("bar"), http.StatusConflict, "foo")
+
+	data, err := want.(interface{ MarshalBinary() ([]byte, error) }).MarshalBinary()
+	require.NoError(t, err)
+
+	got, err := errorsx.DecodeBinary(data)
+	require.NoError(t, err)
+	assert.Equal(t, want.Error(), got.Error())
+	assert.Equal(t, want.Stack(), got.Stack())
+}
+
+func TestDecodeJSON_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "no layers",
+			data: `{"message":"foo"}`,
+		},
+		{
+			name: "innermost layer is not an error layer",
+			data: `{"layers":[{"type":"http","status":404}]}`,
+		},
+		{
+			name: "unknown layer type",
+			data: `{"layers":[{"type":"foo"},{"type":"error","message":"bar"}]}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := errorsx.DecodeJSON([]byte(tc.data))
+			assert.ErrorIs(t, err, errorsx.ErrInvalidEncoding)
+		})
+	}
+}
@@ -3,133 +3,315 @@ package errorsx
 import (
 	"errors"
 	"fmt"
-	"runtime"
+	"log/slog"
+	"maps"
 	"slices"
 	"strings"
-
-	"github.com/go-playground/validator/v10"
 )
 
 type ErrorX interface {
 	error
+	Layer
 
 	Caller() string
 	Stack() Stack
 	Fields(fields ...string) map[string]any
 	Wrap(err error) ErrorX
+	With(args ...any) ErrorX
 	Unwrap() error
+}
 
-	// helpers
-	string() string
-	unwrap() ErrorX
-	fields() map[string]any
+// Layer is a single link of an ErrorX chain. Types outside this package
+// become layers by embedding the ErrorX they decorate and implementing Layer,
+// the way the layers of grpcx and retry do. The methods promoted from the
+// embedded ErrorX would leave the layer out of the chain, so every one of
+// them but Caller and Stack must be overridden:
+//
+//	Error()        returns Stringify(e)
+//	Fields(f...)   returns Mapify(e, f...)
+//	Unwrap()       returns e.Inner(), so errors.As reaches the layers below
+//	Wrap(err)      on a copy of the layer, replaces the embedded ErrorX
+//	               with its Wrap(err) and returns the copy
+//	With(args...)  returns With(e, args...)
+//	Inner()        returns the embedded ErrorX
+//
+// Layers render like the built-in ones by delegating MarshalJSON to
+// EncodeJSON, fmt.Formatter to Format and slog.LogValuer to LogValue.
+type Layer interface {
+	// LayerMessage returns the message this layer adds to Error().
+	LayerMessage() string
+	// LayerFields returns the fields this layer adds to Fields().
+	LayerFields() map[string]any
+	// Inner returns the ErrorX this layer decorates, or nil for the
+	// innermost layer.
+	Inner() ErrorX
 }
 
 type errorX struct {
-	stack   Stack
-	caller  string
-	err     error
-	message string
+	callers    *callers
+	err        error
+	message    string
+	public     string
+	redaction  *RedactionPolicy
+	validation validationOptions
 }
 
-var _ ErrorX = (*errorX)(nil)
+var (
+	_ ErrorX         = (*errorX)(nil)
+	_ PublicMessager = (*errorX)(nil)
+)
 
 func (e *errorX) Error() string {
 	return stringify(e)
 }
 
-func (e *errorX) string() string {
-	msg := e.message
-	if e.err != nil {
-		msg = msg + ": " + e.err.Error()
+func (e *errorX) LayerMessage() string {
+	return e.layerMessage(false)
+}
+
+// layerMessage returns the message of e followed by the one of the wrapped
+// error, whose ErrorX values leave out their caller when bare is set.
+func (e *errorX) layerMessage(bare bool) string {
+	switch {
+	case e.err == nil:
+		return e.message
+	case e.message == "":
+		return e.errorMessage(bare)
+	default:
+		return e.message + ": " + e.errorMessage(bare)
 	}
+}
 
-	return msg
+// errorMessage renders the wrapped error, with its validation failures
+// translated when a translator was given.
+func (e *errorX) errorMessage(bare bool) string {
+	return renderError(e.err, e.validation.translator, bare)
 }
 
 func (e errorX) Wrap(err error) ErrorX {
 	e.err = errors.Join(e.err, err)
-	switch et := err.(type) {
-	case validator.ValidationErrors:
+	if fieldErrs, ok := validationErrors(err); ok {
 		return &validationErrorX{
 			ErrorX:      &e,
-			fieldErrors: et,
+			fieldErrors: fieldErrs,
+			validation:  e.validation,
 		}
 	}
 
 	return &e
 }
 
+func (e *errorX) With(args ...any) ErrorX {
+	return With(e, args...)
+}
+
 func (e *errorX) Unwrap() error {
 	return e.err
 }
 
+func (e *errorX) PublicMessage() string {
+	if e.public != "" {
+		return e.public
+	}
+
+	return e.message
+}
+
 func (e *errorX) Fields(fields ...string) map[string]any {
 	return mapify(e, fields)
 }
 
 func (e *errorX) Caller() string {
-	return e.caller
+	return e.callers.Caller()
 }
 
 func (e *errorX) Stack() Stack {
-	return e.stack
+	return e.callers.Stack()
 }
 
-func (e errorX) unwrap() ErrorX {
+func (e errorX) Inner() ErrorX {
 	return nil
 }
 
-func (e *errorX) fields() map[string]any {
+func (e *errorX) LayerFields() map[string]any {
 	fields := map[string]any{"message": e.message}
 	if e.err != nil {
-		fields["error"] = e.err.Error()
+		fields["error"] = e.errorMessage(false)
 	}
 
 	return fields
 }
 
-func New(message string) ErrorX {
-	return newf(nil, "%s", message)
+func (e *errorX) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *errorX) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *errorX) MarshalText() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *errorX) UnmarshalText(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *errorX) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(e)
+}
+
+func (e *errorX) UnmarshalBinary(data []byte) error {
+	return unmarshalInto(e, DecodeBinary, data)
+}
+
+func (e *errorX) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+func (e *errorX) LogValue() slog.Value {
+	return logValue(e)
+}
+
+func (e *errorX) encodeLayer() jsonLayer {
+	l := jsonLayer{
+		Type:          layerTypeError,
+		Message:       e.message,
+		PublicMessage: e.public,
+		Caller:        e.Caller(),
+		Stack:         e.Stack(),
+	}
+
+	if e.err != nil {
+		cause := encodeError(e.err)
+		l.Cause = &cause
+	}
+
+	return l
+}
+
+func New(message string, opts ...Option) ErrorX {
+	return newf(nil, opts, "%s", message)
 }
 
+// Newf creates an error with a message formatted from format and args. The
+// formatted constructors take no Option, as the arguments are variadic;
+// pass fmt.Sprintf(format, args...) to New for an error with options.
 func Newf(format string, args ...any) ErrorX {
-	return newf(nil, format, args...)
+	return newf(nil, nil, format, args...)
 }
 
-func NewWithError(err error, message string) ErrorX {
-	return newf(err, "%s", message)
+func NewWithError(err error, message string, opts ...Option) ErrorX {
+	return newf(err, opts, "%s", message)
 }
 
+// NewWithErrorf creates an error wrapping err the way NewWithError does,
+// with a message formatted as Newf. It takes no Option; pass
+// fmt.Sprintf(format, args...) to NewWithError for an error with options.
 func NewWithErrorf(err error, format string, args ...any) ErrorX {
-	return newf(err, format, args...)
+	return newf(err, nil, format, args...)
 }
 
-func newf(err error, format string, args ...any) ErrorX {
+// newf builds the innermost layer of every constructor. It must be called
+// directly from the exported constructor so the captured stack starts at the
+// constructor's caller.
+func newf(err error, opts []Option, format string, args ...any) ErrorX {
+	o := newOptions(opts)
 	newErrorX := &errorX{
-		err:     err,
-		message: fmt.Sprintf(format, args...),
-		caller:  getCaller(2),
-		stack:   getStack(4),
+		err:        err,
+		message:    fmt.Sprintf(format, args...),
+		public:     o.publicMessage,
+		redaction:  o.redactionPolicy,
+		callers:    o.callers,
+		validation: o.validation(),
+	}
+	if newErrorX.callers == nil {
+		newErrorX.callers = getCallers(3, o.stack())
 	}
 
-	switch e := err.(type) {
-	case validator.ValidationErrors:
-		return &validationErrorX{
+	var ex ErrorX = newErrorX
+	if fieldErrs, ok := validationErrors(err); ok {
+		ex = &validationErrorX{
 			ErrorX:      newErrorX,
-			fieldErrors: e,
+			fieldErrors: fieldErrs,
+			validation:  newErrorX.validation,
 		}
-	default:
-		return newErrorX
 	}
+
+	if len(o.contextFields) != 0 {
+		ex = &attrErrorX{ErrorX: ex, attrs: o.contextFields}
+	}
+
+	return ex
+}
+
+// Stringify renders the chain of e the way Error() does: every layer
+// message from the innermost outwards, followed by the innermost caller when
+// one was captured.
+func Stringify(e ErrorX) string {
+	return stringify(e)
+}
+
+// Mapify merges the fields of every layer of e the way Fields() does,
+// keeping only the given keys or dotted paths, as Projection describes them,
+// when any are provided and hiding sensitive values following the
+// RedactionPolicy of e.
+func Mapify(e ErrorX, fields ...string) map[string]any {
+	return mapify(e, fields)
+}
+
+// Format implements fmt.Formatter for the chain of e the way the built-in
+// layers do.
+func Format(e ErrorX, s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+// Message returns the text of err with every ErrorX it holds, directly,
+// joined or wrapped, rendered as the message chain %s prints, without its
+// caller. The text other wrappers add, such as the "op: " of
+// fmt.Errorf("op: %w", err), is kept.
+func Message(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return renderError(err, nil, true)
+}
+
+// LogValue builds the slog.Value the built-in layers return from LogValue:
+// a group of the fields of e.
+func LogValue(e ErrorX) slog.Value {
+	return logValue(e)
 }
 
 func stringify(e ErrorX) string {
+	msg, innermost := messages(e)
+	if caller := innermost.Caller(); caller != "" {
+		msg = msg + " [" + caller + "]"
+	}
+
+	return msg
+}
+
+// messages joins the layer messages of e the way Error() does, without the
+// trailing caller, and returns the innermost layer of e alongside.
+func messages(e ErrorX) (string, ErrorX) {
+	return messageChain(e, false)
+}
+
+// bareMessages is messages with the ErrorX values wrapped by the innermost
+// layer rendered without their caller, the message chain %s prints.
+func bareMessages(e ErrorX) (string, ErrorX) {
+	return messageChain(e, true)
+}
+
+func messageChain(e ErrorX, bare bool) (string, ErrorX) {
 	ex := ErrorX(e)
 	msgs := make([]string, 1)
 	for {
-		if eu := ex.unwrap(); eu != nil {
-			msg := ex.string()
+		if eu := ex.Inner(); eu != nil {
+			msg := ex.LayerMessage()
 			if msg != "" {
 				msgs = append(msgs, msg)
 			}
@@ -137,49 +319,133 @@ func stringify(e ErrorX) string {
 			continue
 		}
 
-		msg := ex.string()
-
-		msgs[0] = msg
+		msgs[0] = innermostMessage(ex, bare)
 
-		msg = strings.Join(msgs, ": ")
-		msg = msg + " [" + ex.Caller() + "]"
+		return strings.Join(msgs, ": "), ex
+	}
+}
 
-		return msg
+// innermostMessage returns the LayerMessage of the innermost layer ex, bare
+// as messageChain asks.
+func innermostMessage(ex ErrorX, bare bool) string {
+	if x, ok := ex.(*errorX); ok {
+		return x.layerMessage(bare)
 	}
+
+	return ex.LayerMessage()
 }
 
 func mapify(e ErrorX, fields []string) map[string]any {
+	return project(e, Projection{Include: fields})
+}
+
+func project(e ErrorX, p Projection) map[string]any {
 	ex := ErrorX(e)
 	f := make(map[string]any)
 	for {
-		if eu := ex.unwrap(); eu != nil {
-			mapCopy(f, ex.fields(), fields)
+		if eu := ex.Inner(); eu != nil {
+			mapCopy(f, ex.LayerFields(), p.wants)
 			ex = eu
 			continue
 		}
 
-		mapCopy(f, ex.fields(), fields)
-		if len(fields) == 0 || slices.Contains(fields, "caller") {
+		mapCopy(f, ex.LayerFields(), p.wants)
+		if p.wants("caller") {
 			f["caller"] = ex.Caller()
 		}
 
-		if len(fields) == 0 || slices.Contains(fields, "caller") {
-			f["stack"] = ex.Stack()
+		if p.wants("stack") {
+			f["stack"] = ex.Stack().Filter(GetStackFilter())
 		}
 
-		return f
+		return p.apply(redactionOf(e).redactFields(f))
 	}
 }
 
-func mapCopy(dst, src map[string]any, keys []string) {
+func mapCopy(dst, src map[string]any, keep func(string) bool) {
 	for k, v := range src {
-		if len(keys) == 0 || slices.Contains(keys, k) {
-			dst[k] = v
+		if keep(k) {
+			dst[k] = mergeField(dst[k], v)
+		}
+	}
+}
+
+// mergeField combines the value an inner layer gives a field, src, with the
+// one outer layers gave it, dst. Maps are merged key by key and failure lists
+// appended, such as the validation failures of several validation layers, a
+// failure list meeting a map going under its "_errors" key. Otherwise the
+// outer value wins.
+func mergeField(dst, src any) any {
+	switch s := src.(type) {
+	case map[string]any:
+		var m map[string]any
+		switch d := dst.(type) {
+		case map[string]any:
+			m = maps.Clone(d)
+		case []map[string]any:
+			m = map[string]any{"_errors": d}
+		case nil:
+			return s
+		default:
+			return d
+		}
+
+		for k, v := range s {
+			m[k] = mergeField(m[k], v)
+		}
+		return m
+	case []map[string]any:
+		switch d := dst.(type) {
+		case []map[string]any:
+			return append(slices.Clip(d), s...)
+		case map[string]any:
+			m := maps.Clone(d)
+			m["_errors"] = mergeField(m["_errors"], s)
+			return m
 		}
 	}
+
+	if dst != nil {
+		return dst
+	}
+
+	return src
 }
 
-func getCaller(skip int) string {
-	pc, file, line, _ := runtime.Caller(1 + skip)
-	return fmt.Sprintf("%s %s:%d", runtime.FuncForPC(pc).Name(), file, line)
+// Is reports whether any error in the chain of err matches target. It is
+// errors.Is, so a *Code target matches every error carrying that code.
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+// As finds the first error in the chain of err that matches target. It is
+// errors.As.
+func As(err error, target any) bool {
+	return errors.As(err, target)
+}
+
+// walk calls fn for err and for every error reachable from it through
+// Unwrap() error or Unwrap() []error, depth-first. It stops as soon as fn
+// returns false and reports whether the walk ran to completion.
+func walk(err error, fn func(error) bool) bool {
+	if err == nil {
+		return true
+	}
+
+	if !fn(err) {
+		return false
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if !walk(e, fn) {
+				return false
+			}
+		}
+	case interface{ Unwrap() error }:
+		return walk(u.Unwrap(), fn)
+	}
+
+	return true
 }
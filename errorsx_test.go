@@ -3,11 +3,15 @@ package errorsx_test
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"runtime"
 	"testing"
 
 	"github.com/caioreix/errorsx"
+	errorsxmock "github.com/caioreix/errorsx/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestErrorX_New(t *testing.T) {
@@ -207,6 +211,142 @@ func TestErrorX_Unwrap(t *testing.T) {
 	}
 }
 
+type resourceErrorX struct {
+	errorsx.ErrorX
+
+	resource string
+}
+
+func (e *resourceErrorX) Error() string {
+	return errorsx.Stringify(e)
+}
+
+func (e *resourceErrorX) Unwrap() error {
+	return e.Inner()
+}
+
+func (e resourceErrorX) Wrap(err error) errorsx.ErrorX {
+	e.ErrorX = e.ErrorX.Wrap(err)
+	return &e
+}
+
+func (e *resourceErrorX) With(args ...any) errorsx.ErrorX {
+	return errorsx.With(e, args...)
+}
+
+func (e *resourceErrorX) Fields(fields ...string) map[string]any {
+	return errorsx.Mapify(e, fields...)
+}
+
+func (e *resourceErrorX) LayerMessage() string {
+	return "resource " + e.resource
+}
+
+func (e *resourceErrorX) LayerFields() map[string]any {
+	return map[string]any{"resource": e.resource}
+}
+
+func (e resourceErrorX) Inner() errorsx.ErrorX {
+	return e.ErrorX
+}
+
+func (e *resourceErrorX) Format(s fmt.State, verb rune) {
+	errorsx.Format(e, s, verb)
+}
+
+func (e *resourceErrorX) LogValue() slog.Value {
+	return errorsx.LogValue(e)
+}
+
+func TestErrorX_CustomLayer(t *testing.T) {
+	t.Parallel()
+	var (
+		err      = fmt.Errorf("fake error")
+		msg      = "foo"
+		resource = "user"
+		status   = http.StatusNotFound
+	)
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+		rx := callerRX(fmt.Sprintf("%s: resource %s", msg, resource))
+		errX := &resourceErrorX{ErrorX: errorsx.New(msg), resource: resource}
+
+		got := errX.Error()
+		assert.Regexp(t, rx, got)
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		t.Parallel()
+		rx := callerRX(fmt.Sprintf("%s: %s: resource %s", msg, err.Error(), resource))
+		errX := (&resourceErrorX{ErrorX: errorsx.New(msg), resource: resource}).Wrap(err)
+
+		got := errX.Error()
+		assert.Regexp(t, rx, got)
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		t.Parallel()
+		want := map[string]any{
+			"message":  msg,
+			"resource": resource,
+			"status":   status,
+		}
+
+		errX := &resourceErrorX{ErrorX: errorsx.NewHTTP(status, msg), resource: resource}
+		got := errX.Fields("message", "resource", "status")
+		assert.Equal(t, want, got)
+
+		gotStatus, ok := errorsx.HTTPStatus(errX)
+		assert.True(t, ok)
+		assert.Equal(t, status, gotStatus)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		t.Parallel()
+		errX := &resourceErrorX{ErrorX: errorsx.NewHTTP(status, msg), resource: resource}
+
+		assert.Equal(t, resource, errX.Wrap(err).Fields("resource")["resource"])
+		assert.Equal(t, resource, errX.With("user_id", 42).Fields("resource")["resource"])
+
+		var hs errorsx.HTTPStatuser
+		require.ErrorAs(t, errX, &hs)
+		assert.Equal(t, status, hs.HTTPStatus())
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		t.Parallel()
+		errX := &resourceErrorX{ErrorX: errorsx.New(msg, errorsx.WithStackMode(errorsx.StackNone)), resource: resource}
+
+		assert.Equal(t, msg+": resource "+resource, fmt.Sprintf("%s", errX))
+		assert.Equal(t, msg+": resource "+resource+"\nuser_id=42", fmt.Sprintf("%+v", errX.With("user_id", 42)))
+	})
+
+	t.Run("LogValue", func(t *testing.T) {
+		t.Parallel()
+		errX := &resourceErrorX{ErrorX: errorsx.New(msg), resource: resource}
+
+		got := errX.LogValue()
+		assert.Equal(t, slog.KindGroup, got.Kind())
+		assert.Contains(t, got.Group(), slog.String("resource", resource))
+	})
+}
+
+func TestStringify_Mock(t *testing.T) {
+	t.Parallel()
+	inner := errorsxmock.NewErrorX(t)
+	inner.EXPECT().Inner().Return(nil)
+	inner.EXPECT().LayerMessage().Return("inner")
+	inner.EXPECT().Caller().Return("caller")
+
+	outer := errorsxmock.NewErrorX(t)
+	outer.EXPECT().Inner().Return(inner)
+	outer.EXPECT().LayerMessage().Return("outer")
+
+	got := errorsx.Stringify(outer)
+	assert.Equal(t, "inner: outer [caller]", got)
+}
+
 func callerRX(msg string, skip ...int) string {
 	skipT := 0
 	for _, s := range skip {
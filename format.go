@@ -0,0 +1,85 @@
+package errorsx
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// format implements fmt.Formatter for every built-in layer:
+//
+//	%s   the message chain, without the caller of e or of the ErrorX
+//	     values it wraps
+//	%q   the message chain, double-quoted
+//	%v   the same as Error()
+//	%+v  the message chain followed by the attributes as sorted key=value
+//	     pairs on one line and the filtered stack, one function and
+//	     file:line pair per frame, then every ErrorX found in the cause
+//	     chain in the same layout, introduced by "caused by: "
+func format(e ErrorX, s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			writeVerbose(s, e)
+			return
+		}
+		_, _ = io.WriteString(s, stringify(e))
+	case 's':
+		msg, _ := bareMessages(e)
+		_, _ = io.WriteString(s, msg)
+	case 'q':
+		msg, _ := bareMessages(e)
+		_, _ = io.WriteString(s, strconv.Quote(msg))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(%s)", verb, stringify(e))
+	}
+}
+
+func writeVerbose(w io.Writer, e ErrorX) {
+	msg, innermost := bareMessages(e)
+	_, _ = io.WriteString(w, msg)
+
+	if attrs := layerAttributes(e); len(attrs) != 0 {
+		attrs = redactionOf(e).redactFields(attrs)
+		pairs := make([]string, 0, len(attrs))
+		for _, k := range slices.Sorted(maps.Keys(attrs)) {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", k, attrs[k]))
+		}
+		_, _ = io.WriteString(w, "\n"+strings.Join(pairs, " "))
+	}
+
+	stack := innermost.Stack().Filter(GetStackFilter())
+	if len(stack) == 0 && innermost.Caller() != "" {
+		_, _ = io.WriteString(w, "\n"+innermost.Caller())
+	}
+	for _, sf := range stack {
+		_, _ = fmt.Fprintf(w, "\n%s\n\t%s:%d", sf.Function, sf.File, sf.Line)
+	}
+
+	for _, cause := range errorXCauses(innermost.Unwrap()) {
+		_, _ = io.WriteString(w, "\ncaused by: ")
+		writeVerbose(w, cause)
+	}
+}
+
+// errorXCauses returns the ErrorX values found in the chain of err, without
+// descending into them.
+func errorXCauses(err error) []ErrorX {
+	var causes []ErrorX
+	switch e := err.(type) {
+	case nil:
+	case ErrorX:
+		causes = append(causes, e)
+	case interface{ Unwrap() []error }:
+		for _, u := range e.Unwrap() {
+			causes = append(causes, errorXCauses(u)...)
+		}
+	case interface{ Unwrap() error }:
+		causes = append(causes, errorXCauses(e.Unwrap())...)
+	}
+
+	return causes
+}
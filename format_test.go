@@ -0,0 +1,108 @@
+package errorsx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorX_Format(t *testing.T) {
+	t.Parallel()
+	var (
+		err    = fmt.Errorf("fake error")
+		msg    = "foo"
+		status = http.StatusNotFound
+		chain  = fmt.Sprintf("%s: %s: status %d", msg, err.Error(), status)
+	)
+
+	errX := errorsx.NewHTTPWithError(err, status, msg)
+
+	t.Run("%s", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, chain, fmt.Sprintf("%s", errX))
+	})
+
+	t.Run("%s with ErrorX cause", func(t *testing.T) {
+		t.Parallel()
+		wrapped := errorsx.NewHTTPWithError(errorsx.New("inner"), status, "outer")
+		assert.Equal(t, "outer: inner: status 404", fmt.Sprintf("%s", wrapped))
+		assert.Equal(t, strconv.Quote("outer: inner: status 404"), fmt.Sprintf("%q", wrapped))
+
+		joined := errorsx.New(msg).Wrap(errorsx.New("bar")).Wrap(fmt.Errorf("baz: %w", errorsx.New("qux")))
+		assert.Equal(t, msg+": bar\nbaz: qux", fmt.Sprintf("%s", joined))
+	})
+
+	t.Run("%q", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, strconv.Quote(chain), fmt.Sprintf("%q", errX))
+	})
+
+	t.Run("%v", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, errX.Error(), fmt.Sprintf("%v", errX))
+	})
+
+	t.Run("%+v", func(t *testing.T) {
+		t.Parallel()
+		var want strings.Builder
+		want.WriteString(chain)
+		for _, sf := range errX.Stack() {
+			fmt.Fprintf(&want, "\n%s\n\t%s:%d", sf.Function, sf.File, sf.Line)
+		}
+
+		assert.Equal(t, want.String(), fmt.Sprintf("%+v", errX))
+	})
+
+	t.Run("%+v with ErrorX cause", func(t *testing.T) {
+		t.Parallel()
+		cause := errorsx.New("bar")
+		wrapped := errorsx.New(msg).Wrap(fmt.Errorf("baz: %w", cause))
+
+		got := fmt.Sprintf("%+v", wrapped)
+		parts := strings.Split(got, "\ncaused by: ")
+		require.Len(t, parts, 2)
+		assert.True(t, strings.HasPrefix(parts[0], msg+": baz: bar\n"+wrapped.Stack()[0].Function))
+		assert.Equal(t, fmt.Sprintf("%+v", cause), parts[1])
+	})
+
+	t.Run("%+v without stack", func(t *testing.T) {
+		t.Parallel()
+		noStack := errorsx.New(msg, errorsx.WithStackMode(errorsx.StackNone))
+		assert.Equal(t, msg, fmt.Sprintf("%+v", noStack))
+	})
+
+	t.Run("unknown verb", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, "%!d("+errX.Error()+")", fmt.Sprintf("%d", errX))
+	})
+}
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil"},
+		{name: "plain", err: fmt.Errorf("foo"), want: "foo"},
+		{name: "ErrorX", err: errorsx.NewHTTP(http.StatusNotFound, "foo"), want: "foo: status 404"},
+		{name: "wrapped", err: fmt.Errorf("op: %w", errorsx.New("foo")), want: "op: foo"},
+		{name: "joined", err: errors.Join(errorsx.New("foo"), fmt.Errorf("bar")), want: "foo\nbar"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, errorsx.Message(tc.err))
+		})
+	}
+}
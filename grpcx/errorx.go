@@ -0,0 +1,89 @@
+package grpcx
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/caioreix/errorsx"
+	"google.golang.org/grpc/status"
+)
+
+// statusErrorX is the outermost layer of errors converted by FromStatus. It
+// keeps the original status so the error converts back to it unchanged.
+type statusErrorX struct {
+	errorsx.ErrorX
+
+	status *status.Status
+	code   *errorsx.Code
+}
+
+var (
+	_ errorsx.ErrorX     = (*statusErrorX)(nil)
+	_ errorsx.ErrorCoder = (*statusErrorX)(nil)
+)
+
+func (e *statusErrorX) Error() string {
+	return errorsx.Stringify(e)
+}
+
+func (e *statusErrorX) Unwrap() error {
+	return e.Inner()
+}
+
+func (e statusErrorX) Wrap(err error) errorsx.ErrorX {
+	e.ErrorX = e.ErrorX.Wrap(err)
+	return &e
+}
+
+func (e *statusErrorX) With(args ...any) errorsx.ErrorX {
+	return errorsx.With(e, args...)
+}
+
+func (e *statusErrorX) Fields(fields ...string) map[string]any {
+	return errorsx.Mapify(e, fields...)
+}
+
+func (e *statusErrorX) LayerMessage() string {
+	return "grpc " + e.status.Code().String()
+}
+
+func (e *statusErrorX) LayerFields() map[string]any {
+	f := map[string]any{"grpc_code": e.status.Code().String()}
+	if e.code != nil {
+		f["code"] = e.code.Code
+	}
+
+	return f
+}
+
+func (e statusErrorX) Inner() errorsx.ErrorX {
+	return e.ErrorX
+}
+
+func (e *statusErrorX) MarshalJSON() ([]byte, error) {
+	return errorsx.EncodeJSON(e)
+}
+
+func (e *statusErrorX) Format(s fmt.State, verb rune) {
+	errorsx.Format(e, s, verb)
+}
+
+func (e *statusErrorX) LogValue() slog.Value {
+	return errorsx.LogValue(e)
+}
+
+// GRPCStatus returns the status the error was converted from.
+func (e *statusErrorX) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// ErrorCode returns the Code named by the ErrorInfo detail of the status, or
+// nil when it names no defined Code.
+func (e *statusErrorX) ErrorCode() *errorsx.Code {
+	return e.code
+}
+
+// Is reports whether target carries the Code of e.
+func (e *statusErrorX) Is(target error) bool {
+	return e.code != nil && e.code.Is(target)
+}
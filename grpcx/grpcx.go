@@ -0,0 +1,285 @@
+// Package grpcx converts errorsx errors to and from gRPC statuses and
+// provides interceptors applying the conversion on both ends of a call.
+package grpcx
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/caioreix/errorsx"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// DefaultDomain is the ErrorInfo domain used when none is configured.
+const DefaultDomain = "errorsx"
+
+// Option configures Status and the interceptors.
+type Option func(*config)
+
+type config struct {
+	domain    string
+	debugInfo bool
+}
+
+func newConfig(opts []Option) config {
+	c := config{domain: DefaultDomain, debugInfo: errorsx.GetDebug()}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// WithDomain sets the domain of the ErrorInfo detail carrying error codes.
+func WithDomain(domain string) Option {
+	return func(c *config) {
+		c.domain = domain
+	}
+}
+
+// WithDebugInfo overrides the errorsx debug mode in deciding whether the
+// caller and stack of errors go into converted statuses, and whether errors
+// other than ErrorX keep their message.
+func WithDebugInfo(on bool) Option {
+	return func(c *config) {
+		c.debugInfo = on
+	}
+}
+
+// Status converts err into a gRPC status. Errors that already carry a status
+// and no ErrorX keep it. Other errors without an ErrorX become codes.Unknown
+// with the public message of err, or their own message in debug mode only.
+// Otherwise the message is the public message of err
+// and the code is the gRPC code of the error Code, else the mapping of its
+// HTTP status, else codes.Unknown. Statuses below 400 give codes.Unknown too,
+// so an error never converts to OK. Validation errors become a BadRequest
+// detail, located by errorsx.FieldPath and described by errorsx.FieldMessages,
+// and the error Code an ErrorInfo detail. The caller and stack become a
+// DebugInfo detail in debug mode only.
+func Status(err error, opts ...Option) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	c := newConfig(opts)
+
+	var ex errorsx.ErrorX
+	if !errors.As(err, &ex) {
+		var gs interface{ GRPCStatus() *status.Status }
+		if errors.As(err, &gs) {
+			return gs.GRPCStatus()
+		}
+		if c.debugInfo {
+			return status.New(codes.Unknown, err.Error())
+		}
+		return status.New(codes.Unknown, errorsx.PublicMessage(err))
+	}
+
+	if s, ok := errStatus(ex); ok {
+		return s
+	}
+
+	code := codes.Unknown
+	if httpStatus, ok := errorsx.HTTPStatus(err); ok && httpStatus >= http.StatusBadRequest {
+		code = CodeFromHTTPStatus(httpStatus)
+	}
+
+	errCode, hasErrCode := errorsx.ErrorCode(err)
+	if hasErrCode && errCode.GRPCCode != 0 {
+		code = codes.Code(errCode.GRPCCode)
+	}
+
+	s := status.New(code, errorsx.PublicMessage(err))
+
+	var details []protoadapt.MessageV1
+	if fieldErrs, ok := errorsx.FieldErrors(err); ok {
+		br := &errdetails.BadRequest{}
+		msgs := errorsx.FieldMessages(err)
+		for i, fe := range fieldErrs {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       errorsx.FieldPath(fe),
+				Description: msgs[i],
+				Reason:      fe.Tag(),
+			})
+		}
+		details = append(details, br)
+	}
+
+	if hasErrCode {
+		details = append(details, &errdetails.ErrorInfo{Reason: errCode.Code, Domain: c.domain})
+	}
+
+	if c.debugInfo {
+		di := &errdetails.DebugInfo{Detail: ex.Caller()}
+		for _, sf := range ex.Stack() {
+			di.StackEntries = append(di.StackEntries, sf.Function+" "+sf.File+":"+strconv.Itoa(sf.Line))
+		}
+		details = append(details, di)
+	}
+
+	if len(details) == 0 {
+		return s
+	}
+
+	sd, derr := s.WithDetails(details...)
+	if derr != nil {
+		return s
+	}
+
+	return sd
+}
+
+// errStatus returns the status carried by a layer of ex, such as the one of
+// an error converted by FromStatus.
+func errStatus(ex errorsx.ErrorX) (*status.Status, bool) {
+	for l := ex; l != nil; l = l.Inner() {
+		if gs, ok := l.(interface{ GRPCStatus() *status.Status }); ok {
+			return gs.GRPCStatus(), true
+		}
+	}
+
+	return nil, false
+}
+
+// FromStatus converts s into an ErrorX with its message, the HTTP status
+// mapped from its code, and the validation errors, error Code, caller and
+// stack found in its details. The result reports s from GRPCStatus, so
+// converting it back yields s unchanged. It returns nil for an OK status.
+func FromStatus(s *status.Status) errorsx.ErrorX {
+	if s.Code() == codes.OK {
+		return nil
+	}
+
+	var (
+		violations = errorsx.NewValidation()
+		errCode    *errorsx.Code
+		opts       = []errorsx.Option{errorsx.WithStackMode(errorsx.StackNone)}
+	)
+	for _, d := range s.Details() {
+		switch d := d.(type) {
+		case *errdetails.BadRequest:
+			for _, fv := range d.GetFieldViolations() {
+				violations.Add(fv.GetField(), fv.GetReason(), fv.GetDescription())
+			}
+		case *errdetails.ErrorInfo:
+			errCode, _ = errorsx.LookupCode(d.GetReason())
+		case *errdetails.DebugInfo:
+			opts = append(opts, errorsx.WithCallers(d.GetDetail(), parseStack(d.GetStackEntries())))
+		}
+	}
+
+	var base errorsx.ErrorX
+	if fieldErrs := violations.Err(); fieldErrs != nil {
+		base = errorsx.NewHTTPWithError(fieldErrs, HTTPStatusFromCode(s.Code()), s.Message(), opts...)
+	} else {
+		base = errorsx.NewHTTP(HTTPStatusFromCode(s.Code()), s.Message(), opts...)
+	}
+
+	return &statusErrorX{ErrorX: base, status: s, code: errCode}
+}
+
+// FromError converts the gRPC status carried by err with FromStatus. It
+// reports false when err carries no status.
+func FromError(err error) (errorsx.ErrorX, bool) {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() == codes.OK {
+		return nil, false
+	}
+
+	return FromStatus(s), true
+}
+
+func parseStack(entries []string) errorsx.Stack {
+	stack := make(errorsx.Stack, 0, len(entries))
+	for _, entry := range entries {
+		sf := &errorsx.StackFrame{Function: entry}
+		if fn, loc, ok := strings.Cut(entry, " "); ok {
+			sf.Function = fn
+			sf.File = loc
+			if i := strings.LastIndexByte(loc, ':'); i >= 0 {
+				if line, err := strconv.Atoi(loc[i+1:]); err == nil {
+					sf.File, sf.Line = loc[:i], line
+				}
+			}
+		}
+		stack = append(stack, sf)
+	}
+
+	return stack
+}
+
+// CodeFromHTTPStatus maps an HTTP status to the closest gRPC code.
+func CodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusRequestedRangeNotSatisfiable:
+		return codes.OutOfRange
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+
+	switch {
+	case httpStatus >= 500:
+		return codes.Internal
+	case httpStatus >= 400:
+		return codes.FailedPrecondition
+	default:
+		return codes.Unknown
+	}
+}
+
+// HTTPStatusFromCode maps a gRPC code to the closest HTTP status.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
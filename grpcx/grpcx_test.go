@@ -0,0 +1,292 @@
+package grpcx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/caioreix/errorsx/grpcx"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errOrderNotFound = errorsx.DefineCode(errorsx.Code{
+	Code:       "ORDER_NOT_FOUND",
+	Message:    "order not found",
+	HTTPStatus: http.StatusNotFound,
+	GRPCCode:   uint32(codes.NotFound),
+})
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	type Order struct {
+		ID string `validate:"required"`
+	}
+
+	validationErr := validator.New().Struct(Order{})
+
+	tt := []struct {
+		name     string
+		err      error
+		opts     []grpcx.Option
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{
+			name:     "plain error",
+			err:      errors.New("foo"),
+			wantCode: codes.Unknown,
+			wantMsg:  http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name:     "plain error in debug mode",
+			err:      errors.New("foo"),
+			opts:     []grpcx.Option{grpcx.WithDebugInfo(true)},
+			wantCode: codes.Unknown,
+			wantMsg:  "foo",
+		},
+		{
+			name:     "wrapped status error",
+			err:      fmt.Errorf("call: %w", status.Error(codes.Aborted, "foo")),
+			wantCode: codes.Aborted,
+			wantMsg:  "foo",
+		},
+		{
+			name:     "status error",
+			err:      status.Error(codes.Aborted, "foo"),
+			wantCode: codes.Aborted,
+			wantMsg:  "foo",
+		},
+		{
+			name:     "ErrorX",
+			err:      errorsx.NewWithError(errors.New("secret"), "foo"),
+			wantCode: codes.Unknown,
+			wantMsg:  "foo",
+		},
+		{
+			name:     "HTTPErrorX",
+			err:      errorsx.NewHTTP(http.StatusConflict, "foo"),
+			wantCode: codes.AlreadyExists,
+			wantMsg:  "foo",
+		},
+		{
+			name:     "success status",
+			err:      errorsx.NewHTTP(http.StatusOK, "foo"),
+			wantCode: codes.Unknown,
+			wantMsg:  "foo",
+		},
+		{
+			name:     "code",
+			err:      errOrderNotFound.New(),
+			wantCode: codes.NotFound,
+			wantMsg:  "order not found",
+		},
+		{
+			name:     "validation errors",
+			err:      errorsx.NewHTTPWithError(validationErr, http.StatusBadRequest, "foo"),
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "foo",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := grpcx.Status(tc.err, tc.opts...)
+			assert.Equal(t, tc.wantCode, got.Code())
+			assert.Equal(t, tc.wantMsg, got.Message())
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, grpcx.Status(nil))
+	})
+
+	t.Run("details", func(t *testing.T) {
+		t.Parallel()
+		err := errOrderNotFound.NewWithError(validationErr)
+
+		var (
+			br *errdetails.BadRequest
+			ei *errdetails.ErrorInfo
+			di *errdetails.DebugInfo
+		)
+		for _, d := range grpcx.Status(err, grpcx.WithDomain("example.com"), grpcx.WithDebugInfo(true)).Details() {
+			switch d := d.(type) {
+			case *errdetails.BadRequest:
+				br = d
+			case *errdetails.ErrorInfo:
+				ei = d
+			case *errdetails.DebugInfo:
+				di = d
+			}
+		}
+
+		require.NotNil(t, br)
+		require.Len(t, br.GetFieldViolations(), 1)
+		assert.Equal(t, "ID", br.GetFieldViolations()[0].GetField())
+		assert.Equal(t, "required", br.GetFieldViolations()[0].GetReason())
+
+		require.NotNil(t, ei)
+		assert.Equal(t, "ORDER_NOT_FOUND", ei.GetReason())
+		assert.Equal(t, "example.com", ei.GetDomain())
+
+		require.NotNil(t, di)
+		assert.Equal(t, err.Caller(), di.GetDetail())
+		assert.Len(t, di.GetStackEntries(), len(err.Stack()))
+	})
+
+	t.Run("field violations", func(t *testing.T) {
+		t.Parallel()
+
+		type Item struct {
+			Name string `validate:"required"`
+		}
+		type Cart struct {
+			Items []Item `validate:"dive"`
+		}
+
+		validate := validator.New()
+		trans, _ := ut.New(en.New()).GetTranslator("en")
+		require.NoError(t, en_translations.RegisterDefaultTranslations(validate, trans))
+		err := errorsx.NewHTTPWithError(validate.Struct(Cart{Items: make([]Item, 2)}), http.StatusBadRequest, "foo",
+			errorsx.WithTranslator(trans))
+
+		s := grpcx.Status(err)
+		require.Len(t, s.Details(), 1)
+		br, ok := s.Details()[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+
+		var got []string
+		for _, fv := range br.GetFieldViolations() {
+			got = append(got, fv.GetField()+": "+fv.GetDescription())
+		}
+		assert.Equal(t, []string{
+			"Items[0].Name: Name is a required field",
+			"Items[1].Name: Name is a required field",
+		}, got)
+
+		fieldErrs, ok := errorsx.FieldErrors(grpcx.FromStatus(s))
+		require.True(t, ok)
+		require.Len(t, fieldErrs, 2)
+		assert.Equal(t, "Items[1].Name", errorsx.FieldPath(fieldErrs[1]))
+	})
+
+	t.Run("without debug info", func(t *testing.T) {
+		t.Parallel()
+		for _, d := range grpcx.Status(errorsx.New("foo")).Details() {
+			_, ok := d.(*errdetails.DebugInfo)
+			assert.False(t, ok)
+		}
+	})
+}
+
+func TestFromStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		type Order struct {
+			ID string `validate:"required"`
+		}
+
+		err := errOrderNotFound.NewWithError(validator.New().Struct(Order{}))
+		s := grpcx.Status(err, grpcx.WithDebugInfo(true))
+
+		got := grpcx.FromStatus(s)
+		require.NotNil(t, got)
+
+		assert.ErrorIs(t, got, errOrderNotFound)
+		assert.Equal(t, err.Caller(), got.Caller())
+		assert.Equal(t, err.Stack().String(), got.Stack().String())
+		assert.Equal(t, map[string]any{
+			"message":   "order not found",
+			"status":    http.StatusNotFound,
+			"code":      "ORDER_NOT_FOUND",
+			"grpc_code": "NotFound",
+		}, got.Fields("message", "status", "code", "grpc_code"))
+
+		fieldErrs, ok := errorsx.FieldErrors(got)
+		require.True(t, ok)
+		require.Len(t, fieldErrs, 1)
+		assert.Equal(t, "ID", fieldErrs[0].Field())
+		assert.Equal(t, "required", fieldErrs[0].Tag())
+
+		assert.Same(t, s, grpcx.Status(got))
+	})
+
+	t.Run("without details", func(t *testing.T) {
+		t.Parallel()
+		got := grpcx.FromStatus(status.New(codes.Unavailable, "foo"))
+		require.NotNil(t, got)
+		assert.Equal(t, "foo: grpc Unavailable: status 503", got.Error())
+		assert.Equal(t, "foo: grpc Unavailable: status 503", fmt.Sprintf("%s", got))
+		assert.Equal(t, slog.KindGroup, got.(slog.LogValuer).LogValue().Kind())
+
+		data, err := json.Marshal(got)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"grpc_code":"Unavailable"`)
+
+		_, ok := errorsx.ErrorCode(got)
+		assert.False(t, ok)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, grpcx.FromStatus(status.New(codes.OK, "")))
+	})
+}
+
+func TestFromError(t *testing.T) {
+	t.Parallel()
+
+	got, ok := grpcx.FromError(status.Error(codes.PermissionDenied, "foo"))
+	require.True(t, ok)
+	status, _ := errorsx.HTTPStatus(got)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	_, ok = grpcx.FromError(errors.New("foo"))
+	assert.False(t, ok)
+}
+
+func TestCodeFromHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	tt := map[int]codes.Code{
+		http.StatusOK:                  codes.OK,
+		http.StatusBadRequest:          codes.InvalidArgument,
+		http.StatusUnauthorized:        codes.Unauthenticated,
+		http.StatusNotFound:            codes.NotFound,
+		http.StatusTeapot:              codes.FailedPrecondition,
+		http.StatusServiceUnavailable:  codes.Unavailable,
+		http.StatusInternalServerError: codes.Internal,
+		http.StatusBadGateway:          codes.Internal,
+		http.StatusPermanentRedirect:   codes.Unknown,
+	}
+
+	for httpStatus, want := range tt {
+		assert.Equal(t, want, grpcx.CodeFromHTTPStatus(httpStatus), httpStatus)
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	t.Parallel()
+
+	for _, code := range []codes.Code{codes.NotFound, codes.Unauthenticated, codes.Unavailable, codes.ResourceExhausted} {
+		assert.Equal(t, code, grpcx.CodeFromHTTPStatus(grpcx.HTTPStatusFromCode(code)), code)
+	}
+	assert.Equal(t, http.StatusInternalServerError, grpcx.HTTPStatusFromCode(codes.DataLoss))
+}
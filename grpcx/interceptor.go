@@ -0,0 +1,79 @@
+package grpcx
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor converts the errors returned by unary handlers into
+// gRPC status errors with Status.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, Status(err, opts...).Err()
+		}
+
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor converts the errors returned by stream handlers
+// into gRPC status errors with Status.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return Status(err, opts...).Err()
+		}
+
+		return nil
+	}
+}
+
+// UnaryClientInterceptor converts the status errors of unary calls into
+// ErrorX with FromError.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return fromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor converts the status errors of streaming calls,
+// including the ones returned by SendMsg and RecvMsg, into ErrorX with
+// FromError. io.EOF is returned as is.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, fromError(err)
+		}
+
+		return &clientStream{ClientStream: cs}, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	return fromError(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	return fromError(s.ClientStream.RecvMsg(m))
+}
+
+func fromError(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+
+	if ex, ok := FromError(err); ok {
+		return ex
+	}
+
+	return err
+}
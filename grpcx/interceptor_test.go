@@ -0,0 +1,123 @@
+package grpcx_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/caioreix/errorsx/grpcx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// healthServer fails every call with the error registered for the service
+// named in the request.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	errs map[string]error
+}
+
+func (s *healthServer) Check(_ context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, s.errs[req.GetService()]
+}
+
+func (s *healthServer) Watch(req *grpc_health_v1.HealthCheckRequest, _ grpc_health_v1.Health_WatchServer) error {
+	return s.errs[req.GetService()]
+}
+
+func newHealthClient(t *testing.T, errs map[string]error) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcx.UnaryServerInterceptor(grpcx.WithDebugInfo(true))),
+		grpc.StreamInterceptor(grpcx.StreamServerInterceptor(grpcx.WithDebugInfo(true))),
+	)
+	grpc_health_v1.RegisterHealthServer(srv, &healthServer{errs: errs})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(grpcx.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(grpcx.StreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	errs := map[string]error{
+		"code":   errOrderNotFound.New(),
+		"http":   errorsx.NewHTTP(http.StatusTooManyRequests, "slow down"),
+		"plain":  errors.New("foo"),
+		"status": status.Error(codes.Aborted, "bar"),
+	}
+	client := newHealthClient(t, errs)
+
+	tt := []struct {
+		service    string
+		wantCode   codes.Code
+		wantMsg    string
+		wantStatus int
+	}{
+		{service: "code", wantCode: codes.NotFound, wantMsg: "order not found", wantStatus: http.StatusNotFound},
+		{service: "http", wantCode: codes.ResourceExhausted, wantMsg: "slow down", wantStatus: http.StatusTooManyRequests},
+		{service: "plain", wantCode: codes.Unknown, wantMsg: "foo", wantStatus: http.StatusInternalServerError},
+		{service: "status", wantCode: codes.Aborted, wantMsg: "bar", wantStatus: http.StatusConflict},
+	}
+
+	for _, tc := range tt {
+		t.Run("unary "+tc.service, func(t *testing.T) {
+			t.Parallel()
+			_, err := client.Check(t.Context(), &grpc_health_v1.HealthCheckRequest{Service: tc.service})
+			assertErrorX(t, err, tc.wantCode, tc.wantMsg, tc.wantStatus)
+		})
+
+		t.Run("stream "+tc.service, func(t *testing.T) {
+			t.Parallel()
+			stream, err := client.Watch(t.Context(), &grpc_health_v1.HealthCheckRequest{Service: tc.service})
+			require.NoError(t, err)
+
+			_, err = stream.Recv()
+			assertErrorX(t, err, tc.wantCode, tc.wantMsg, tc.wantStatus)
+		})
+	}
+
+	t.Run("code carried over", func(t *testing.T) {
+		t.Parallel()
+		_, err := client.Check(t.Context(), &grpc_health_v1.HealthCheckRequest{Service: "code"})
+		assert.ErrorIs(t, err, errOrderNotFound)
+		assert.Equal(t, errs["code"].(errorsx.ErrorX).Caller(), err.(errorsx.ErrorX).Caller())
+	})
+}
+
+func assertErrorX(t *testing.T, err error, wantCode codes.Code, wantMsg string, wantStatus int) {
+	t.Helper()
+
+	var ex errorsx.ErrorX
+	require.ErrorAs(t, err, &ex)
+	assert.Equal(t, wantCode, status.Code(err))
+	assert.Equal(t, wantMsg, ex.Fields("message")["message"])
+
+	gotStatus, ok := errorsx.HTTPStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, wantStatus, gotStatus)
+}
@@ -1,21 +1,30 @@
 package errorsx
 
 import (
+	"fmt"
+	"log/slog"
 	"strconv"
 )
 
+// HTTPStatuser is implemented by errors that carry an HTTP status code.
+type HTTPStatuser interface {
+	HTTPStatus() int
+}
+
 type httpErrorX struct {
 	ErrorX
 
 	status int
 }
 
+var _ HTTPStatuser = (*httpErrorX)(nil)
+
 func (e *httpErrorX) Error() string {
 	return stringify(e)
 }
 
 func (e *httpErrorX) Unwrap() error {
-	return e.unwrap()
+	return e.Inner()
 }
 
 func (e httpErrorX) Wrap(err error) ErrorX {
@@ -23,7 +32,15 @@ func (e httpErrorX) Wrap(err error) ErrorX {
 	return &e
 }
 
-func (e *httpErrorX) string() string {
+func (e *httpErrorX) With(args ...any) ErrorX {
+	return With(e, args...)
+}
+
+func (e *httpErrorX) HTTPStatus() int {
+	return e.status
+}
+
+func (e *httpErrorX) LayerMessage() string {
 	return "status " + strconv.FormatInt(int64(e.status), 10)
 }
 
@@ -31,38 +48,100 @@ func (e *httpErrorX) Fields(fields ...string) map[string]any {
 	return mapify(e, fields)
 }
 
-func (e httpErrorX) unwrap() ErrorX {
+func (e httpErrorX) Inner() ErrorX {
 	return e.ErrorX
 }
 
-func (e *httpErrorX) fields() map[string]any {
+func (e *httpErrorX) LayerFields() map[string]any {
 	return map[string]any{"status": e.status}
 }
 
-func NewHTTP(status int, message string) ErrorX {
+func (e *httpErrorX) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *httpErrorX) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *httpErrorX) MarshalText() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *httpErrorX) UnmarshalText(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *httpErrorX) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(e)
+}
+
+func (e *httpErrorX) UnmarshalBinary(data []byte) error {
+	return unmarshalInto(e, DecodeBinary, data)
+}
+
+func (e *httpErrorX) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+func (e *httpErrorX) LogValue() slog.Value {
+	return logValue(e)
+}
+
+func (e *httpErrorX) encodeLayer() jsonLayer {
+	return jsonLayer{Type: layerTypeHTTP, Status: e.status}
+}
+
+func NewHTTP(status int, message string, opts ...Option) ErrorX {
 	return &httpErrorX{
-		ErrorX: newf(nil, "%s", message),
+		ErrorX: newf(nil, opts, "%s", message),
 		status: status,
 	}
 }
 
+// NewHTTPf creates an error with status and a message formatted as Newf.
+// It takes no Option; pass fmt.Sprintf(format, args...) to NewHTTP for an
+// error with options.
 func NewHTTPf(status int, format string, args ...any) ErrorX {
 	return &httpErrorX{
-		ErrorX: newf(nil, format, args...),
+		ErrorX: newf(nil, nil, format, args...),
 		status: status,
 	}
 }
 
-func NewHTTPWithError(err error, status int, message string) ErrorX {
+func NewHTTPWithError(err error, status int, message string, opts ...Option) ErrorX {
 	return &httpErrorX{
-		ErrorX: newf(err, "%s", message),
+		ErrorX: newf(err, opts, "%s", message),
 		status: status,
 	}
 }
 
+// NewHTTPWithErrorf creates an error wrapping err with status and a message
+// formatted as Newf. It takes no Option; pass fmt.Sprintf(format, args...)
+// to NewHTTPWithError for an error with options.
 func NewHTTPWithErrorf(err error, status int, format string, args ...any) ErrorX {
 	return &httpErrorX{
-		ErrorX: newf(err, format, args...),
+		ErrorX: newf(err, nil, format, args...),
 		status: status,
 	}
 }
+
+// HTTPStatus returns the status of the outermost HTTPStatuser found in the
+// chain of err, including errors.Join branches. It reports false when no
+// error in the chain carries a status.
+func HTTPStatus(err error) (int, bool) {
+	var (
+		status int
+		found  bool
+	)
+
+	walk(err, func(e error) bool {
+		if hs, ok := e.(HTTPStatuser); ok {
+			status, found = hs.HTTPStatus(), true
+			return false
+		}
+		return true
+	})
+
+	return status, found
+}
@@ -134,3 +134,77 @@ func TestHTTPErrorX_NewHTTPWithErrorf(t *testing.T) {
 	got := errX.Error()
 	assert.Regexp(t, rx, got)
 }
+
+func TestHTTPErrorX_HTTPStatus(t *testing.T) {
+	t.Parallel()
+	var (
+		msg    = "foo"
+		status = http.StatusUnprocessableEntity
+	)
+
+	errX := errorsx.NewHTTP(status, msg)
+	hs, ok := errX.(errorsx.HTTPStatuser)
+	assert.True(t, ok)
+	assert.Equal(t, status, hs.HTTPStatus())
+}
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantOK     bool
+	}{
+		{
+			name:       "nil error",
+			err:        nil,
+			wantStatus: 0,
+			wantOK:     false,
+		},
+		{
+			name:       "without status",
+			err:        errorsx.New("foo"),
+			wantStatus: 0,
+			wantOK:     false,
+		},
+		{
+			name:       "HTTPErrorX",
+			err:        errorsx.NewHTTP(http.StatusNotFound, "foo"),
+			wantStatus: http.StatusNotFound,
+			wantOK:     true,
+		},
+		{
+			name:       "wrapped by fmt.Errorf",
+			err:        fmt.Errorf("bar: %w", errorsx.NewHTTP(http.StatusNotFound, "foo")),
+			wantStatus: http.StatusNotFound,
+			wantOK:     true,
+		},
+		{
+			name:       "joined through Wrap",
+			err:        errorsx.New("foo").Wrap(errorsx.NewHTTP(http.StatusConflict, "bar")),
+			wantStatus: http.StatusConflict,
+			wantOK:     true,
+		},
+		{
+			name: "outermost status wins",
+			err: errorsx.NewHTTPWithError(
+				errorsx.NewHTTP(http.StatusNotFound, "bar"),
+				http.StatusBadGateway,
+				"foo",
+			),
+			wantStatus: http.StatusBadGateway,
+			wantOK:     true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			gotStatus, gotOK := errorsx.HTTPStatus(tc.err)
+			assert.Equal(t, tc.wantStatus, gotStatus)
+			assert.Equal(t, tc.wantOK, gotOK)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/caioreix/errorsx"
+)
+
+// Encoder writes err as the response to r.
+type Encoder interface {
+	Encode(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// EncoderFunc adapts a function to an Encoder.
+type EncoderFunc func(w http.ResponseWriter, r *http.Request, err error)
+
+func (f EncoderFunc) Encode(w http.ResponseWriter, r *http.Request, err error) {
+	f(w, r, err)
+}
+
+// jsonFields are the Fields() keys JSONEncoder exposes.
+var jsonFields = []string{"message", "status", "code", "validation_errors"}
+
+// ProblemEncoder writes errors as RFC 9457 Problem Details rendered with
+// policy, or errorsx.DefaultProblemPolicy when policy is nil.
+func ProblemEncoder(policy *errorsx.ProblemPolicy) Encoder {
+	return EncoderFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+		_ = errorsx.WriteProblem(w, r, err, policy)
+	})
+}
+
+// JSONEncoder writes errors as a JSON object with their public message,
+// status, code and validation errors, adding their internal fields in the
+// errorsx debug mode. Errors that aren't ErrorX values only expose the
+// status and its text.
+func JSONEncoder() Encoder {
+	return EncoderFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+		status := Status(err)
+		body := map[string]any{
+			"message": http.StatusText(status),
+			"status":  status,
+		}
+
+		var ex errorsx.ErrorX
+		if errors.As(err, &ex) {
+			fields := jsonFields
+			if errorsx.GetDebug() {
+				fields = append(slices.Clip(fields), errorsx.InternalFields()...)
+			}
+
+			for k, v := range ex.Fields(fields...) {
+				body[k] = v
+			}
+			body["message"] = errorsx.PublicMessage(err)
+		}
+
+		data, jerr := json.Marshal(body)
+		if jerr != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	})
+}
+
+// TextEncoder writes errors as plain text: their public message, or the
+// status text for errors that aren't ErrorX values.
+func TextEncoder() Encoder {
+	return EncoderFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+		status := Status(err)
+		msg := http.StatusText(status)
+
+		var ex errorsx.ErrorX
+		if errors.As(err, &ex) {
+			msg = errorsx.PublicMessage(err)
+		}
+
+		http.Error(w, msg, status)
+	})
+}
@@ -0,0 +1,129 @@
+package httpx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/caioreix/errorsx/httpx"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	validationErr := validator.New().Struct(User{})
+
+	tt := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   map[string]any
+	}{
+		{
+			name:       "ErrorX",
+			err:        errorsx.NewHTTPWithError(validationErr, http.StatusBadRequest, "foo"),
+			wantStatus: http.StatusBadRequest,
+			wantBody: map[string]any{
+				"message": "foo",
+				"status":  float64(http.StatusBadRequest),
+				"validation_errors": map[string]any{
+					"User.Name": []any{map[string]any{
+						"tag":              "required",
+						"param":            "",
+						"value":            "",
+						"namespace":        "User.Name",
+						"struct_namespace": "User.Name",
+						"message":          validationErr.(validator.ValidationErrors)[0].Error(),
+					}},
+				},
+			},
+		},
+		{
+			name:       "plain error",
+			err:        errors.New("secret"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody: map[string]any{
+				"message": http.StatusText(http.StatusInternalServerError),
+				"status":  float64(http.StatusInternalServerError),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			httpx.JSONEncoder().Encode(rec, httptest.NewRequest(http.MethodGet, "/", nil), tc.err)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, tc.wantBody, got)
+		})
+	}
+}
+
+func TestJSONEncoder_Debug(t *testing.T) {
+	defer errorsx.SetDebug(errorsx.GetDebug())
+	errX := errorsx.NewHTTPWithError(errors.New("secret"), http.StatusInternalServerError, "foo",
+		errorsx.WithPublicMessage("try again later"))
+
+	tt := []struct {
+		debug    bool
+		wantKeys []string
+	}{
+		{debug: false, wantKeys: []string{"message", "status"}},
+		{debug: true, wantKeys: []string{"message", "status", "error", "caller", "stack"}},
+	}
+
+	for _, tc := range tt {
+		errorsx.SetDebug(tc.debug)
+		rec := httptest.NewRecorder()
+		httpx.JSONEncoder().Encode(rec, httptest.NewRequest(http.MethodGet, "/", nil), errX)
+
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		assert.Equal(t, "try again later", got["message"])
+		assert.Len(t, got, len(tc.wantKeys))
+		for _, k := range tc.wantKeys {
+			assert.Contains(t, got, k)
+		}
+	}
+}
+
+func TestProblemEncoder(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+
+	policy := &errorsx.ProblemPolicy{Extensions: []string{"status"}}
+	httpx.ProblemEncoder(policy).Encode(rec, req, errorsx.NewHTTP(http.StatusNotFound, "foo"))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, errorsx.ProblemContentType, rec.Header().Get("Content-Type"))
+
+	got, err := errorsx.ParseProblem(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"message": "foo", "status": http.StatusNotFound}, got.Fields("message", "status"))
+}
+
+func TestTextEncoder(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	httpx.TextEncoder().Encode(rec, httptest.NewRequest(http.MethodGet, "/", nil), errorsx.NewHTTP(http.StatusTeapot, "foo"))
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "foo\n", rec.Body.String())
+}
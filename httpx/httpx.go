@@ -0,0 +1,209 @@
+// Package httpx adapts errorsx to net/http: handlers that return errors,
+// panic recovery and pluggable error responses.
+package httpx
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/caioreix/errorsx"
+)
+
+// HandlerFunc is an http.Handler that returns its error instead of writing
+// it. Served directly it uses the default options; wrap it with Handler to
+// change them.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	Handler(h).ServeHTTP(w, r)
+}
+
+// ErrorHook is called with every error a handler returns or panics with,
+// before it is written.
+type ErrorHook func(r *http.Request, err error)
+
+// Option configures Handler and Recover.
+type Option func(*config)
+
+type config struct {
+	encoder Encoder
+	hooks   []ErrorHook
+}
+
+func newConfig(opts []Option) config {
+	c := config{encoder: ProblemEncoder(nil)}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// WithEncoder sets the Encoder that writes error responses. It defaults to
+// ProblemEncoder(nil).
+func WithEncoder(e Encoder) Option {
+	return func(c *config) {
+		c.encoder = e
+	}
+}
+
+// WithErrorHook adds a hook called with every error before it is written.
+func WithErrorHook(hook ErrorHook) Option {
+	return func(c *config) {
+		c.hooks = append(c.hooks, hook)
+	}
+}
+
+// SlogHook returns an ErrorHook logging errors to logger, at error level
+// for 5xx statuses and at warn level otherwise.
+func SlogHook(logger *slog.Logger) ErrorHook {
+	return func(r *http.Request, err error) {
+		level := slog.LevelWarn
+		if Status(err) >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		logger.LogAttrs(r.Context(), level, "http request failed",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// Handler serves h, writing the error it returns or panics with through the
+// configured Encoder.
+func Handler(h HandlerFunc, opts ...Option) http.Handler {
+	c := newConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		defer c.recover(rw, r)
+
+		if err := h(rw, r); err != nil {
+			c.handle(rw, r, err)
+		}
+	})
+}
+
+// Recover serves next, writing any panic it raises through the configured
+// Encoder as an ErrorX carrying the panic value and its stack.
+func Recover(next http.Handler, opts ...Option) http.Handler {
+	c := newConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		defer c.recover(rw, r)
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// Status returns the HTTP status err is written with: the one of its
+// outermost HTTPStatuser, or 500.
+func Status(err error) int {
+	if status, ok := errorsx.HTTPStatus(err); ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+func (c config) recover(w *responseWriter, r *http.Request) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+
+	if rec == http.ErrAbortHandler {
+		panic(rec)
+	}
+
+	c.handle(w, r, panicError(rec))
+}
+
+// panicError must be called from the deferred function that recovered rec.
+// Whatever the StackPolicy mode, the error carries a full stack starting at
+// the panic call rather than at the recovery.
+func panicError(rec any) errorsx.ErrorX {
+	err, ok := rec.(error)
+	if !ok {
+		err = fmt.Errorf("%v", rec)
+	}
+
+	opts := []errorsx.Option{errorsx.WithStackMode(errorsx.StackFull)}
+	if stack := panicStack(); len(stack) != 0 {
+		caller := stack[0].Function + " " + stack[0].File + ":" + strconv.Itoa(stack[0].Line)
+		opts = append(opts, errorsx.WithCallers(caller, stack))
+	}
+
+	return errorsx.NewHTTPWithError(err, http.StatusInternalServerError, "panic", opts...)
+}
+
+// panicStack returns the stack of the panicking goroutine up to the MaxDepth
+// of the StackPolicy, leaving out the recovery and runtime frames above the
+// function that panicked. It returns nil outside of a panic.
+func panicStack() errorsx.Stack {
+	depth := errorsx.GetStackPolicy().MaxDepth
+	if depth <= 0 {
+		depth = 32
+	}
+
+	pcs := make([]uintptr, depth+32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	var (
+		stack     errorsx.Stack
+		panicking bool
+	)
+	for len(stack) < depth {
+		frame, more := frames.Next()
+		switch {
+		case frame.Function == "runtime.gopanic":
+			panicking = true
+		case panicking && (len(stack) != 0 || !strings.HasPrefix(frame.Function, "runtime.")):
+			stack = append(stack, &errorsx.StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+func (c config) handle(w *responseWriter, r *http.Request, err error) {
+	for _, hook := range c.hooks {
+		hook(r, err)
+	}
+
+	if w.wroteHeader {
+		return
+	}
+
+	c.encoder.Encode(w, r, err)
+}
+
+// responseWriter records whether the response was started, so errors
+// returned after writing don't corrupt it.
+type responseWriter struct {
+	http.ResponseWriter
+
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,218 @@
+package httpx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/caioreix/errorsx/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name       string
+		handler    httpx.HandlerFunc
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "no error",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				_, _ = w.Write([]byte("ok"))
+				return nil
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name: "HTTPErrorX",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return errorsx.NewHTTP(http.StatusNotFound, "foo")
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   "foo\n",
+		},
+		{
+			name: "plain error",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				return errors.New("foo")
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   http.StatusText(http.StatusInternalServerError) + "\n",
+		},
+		{
+			name: "error after writing",
+			handler: func(w http.ResponseWriter, r *http.Request) error {
+				w.WriteHeader(http.StatusAccepted)
+				return errorsx.NewHTTP(http.StatusNotFound, "foo")
+			},
+			wantStatus: http.StatusAccepted,
+			wantBody:   "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			httpx.Handler(tc.handler, httpx.WithEncoder(httpx.TextEncoder())).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandlerFunc_ServeHTTP(t *testing.T) {
+	t.Parallel()
+	h := httpx.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errorsx.NewHTTP(http.StatusConflict, "foo")
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, errorsx.ProblemContentType, rec.Header().Get("Content-Type"))
+}
+
+func panicking(v any) {
+	panic(v)
+}
+
+func TestHandler_Panic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with value", func(t *testing.T) {
+		t.Parallel()
+		var got error
+		h := httpx.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			panicking("boom")
+			return nil
+		}, httpx.WithErrorHook(func(r *http.Request, err error) { got = err }))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		var errX errorsx.ErrorX
+		require.ErrorAs(t, got, &errX)
+		assert.Contains(t, errX.Error(), "panic: boom")
+		assert.Contains(t, errX.Stack().String(), "httpx_test.panicking")
+		assert.True(t, strings.HasSuffix(errX.Stack()[0].Function, "httpx_test.panicking"))
+		assert.True(t, strings.HasPrefix(errX.Caller(), errX.Stack()[0].Function+" "))
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		t.Parallel()
+		var (
+			panicErr = errors.New("boom")
+			got      error
+		)
+		h := httpx.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			panic(panicErr)
+		}, httpx.WithErrorHook(func(r *http.Request, err error) { got = err }))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.ErrorIs(t, got, panicErr)
+	})
+
+	t.Run("ErrAbortHandler", func(t *testing.T) {
+		t.Parallel()
+		h := httpx.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			panic(http.ErrAbortHandler)
+		})
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
+
+func TestHandler_PanicStackPolicy(t *testing.T) {
+	defer errorsx.SetStackPolicy(errorsx.GetStackPolicy())
+	errorsx.SetStackPolicy(errorsx.StackPolicy{Mode: errorsx.StackNone})
+
+	var got error
+	h := httpx.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		var m map[string]int
+		m["boom"]++
+		return nil
+	}, httpx.WithErrorHook(func(r *http.Request, err error) { got = err }))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var errX errorsx.ErrorX
+	require.ErrorAs(t, got, &errX)
+	require.NotEmpty(t, errX.Stack())
+	assert.Contains(t, errX.Stack()[0].Function, "TestHandler_PanicStackPolicy")
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+	var (
+		panicErr = errorsx.NewHTTP(http.StatusServiceUnavailable, "foo")
+		got      error
+	)
+	h := httpx.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(panicErr)
+	}), httpx.WithEncoder(httpx.JSONEncoder()), httpx.WithErrorHook(func(r *http.Request, err error) { got = err }))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.ErrorIs(t, got, panicErr)
+}
+
+func TestSlogHook(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		err       error
+		wantLevel string
+	}{
+		{
+			name:      "client error",
+			err:       errorsx.NewHTTP(http.StatusNotFound, "foo"),
+			wantLevel: "WARN",
+		},
+		{
+			name:      "server error",
+			err:       errors.New("foo"),
+			wantLevel: "ERROR",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			hook := httpx.SlogHook(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+			hook(httptest.NewRequest(http.MethodPost, "/users", nil), tc.err)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tc.wantLevel, got["level"])
+			assert.Equal(t, http.MethodPost, got["method"])
+			assert.Equal(t, "/users", got["path"])
+			assert.True(t, strings.Contains(buf.String(), "foo"))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package httpxmock
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EncoderFunc is an autogenerated mock type for the EncoderFunc type
+type EncoderFunc struct {
+	mock.Mock
+}
+
+type EncoderFunc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *EncoderFunc) EXPECT() *EncoderFunc_Expecter {
+	return &EncoderFunc_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: w, r, err
+func (_m *EncoderFunc) Execute(w http.ResponseWriter, r *http.Request, err error) {
+	_m.Called(w, r, err)
+}
+
+// EncoderFunc_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type EncoderFunc_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - err error
+func (_e *EncoderFunc_Expecter) Execute(w interface{}, r interface{}, err interface{}) *EncoderFunc_Execute_Call {
+	return &EncoderFunc_Execute_Call{Call: _e.mock.On("Execute", w, r, err)}
+}
+
+func (_c *EncoderFunc_Execute_Call) Run(run func(w http.ResponseWriter, r *http.Request, err error)) *EncoderFunc_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *EncoderFunc_Execute_Call) Return() *EncoderFunc_Execute_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *EncoderFunc_Execute_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, error)) *EncoderFunc_Execute_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewEncoderFunc creates a new instance of EncoderFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEncoderFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EncoderFunc {
+	mock := &EncoderFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,71 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package httpxmock
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Encoder is an autogenerated mock type for the Encoder type
+type Encoder struct {
+	mock.Mock
+}
+
+type Encoder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Encoder) EXPECT() *Encoder_Expecter {
+	return &Encoder_Expecter{mock: &_m.Mock}
+}
+
+// Encode provides a mock function with given fields: w, r, err
+func (_m *Encoder) Encode(w http.ResponseWriter, r *http.Request, err error) {
+	_m.Called(w, r, err)
+}
+
+// Encoder_Encode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Encode'
+type Encoder_Encode_Call struct {
+	*mock.Call
+}
+
+// Encode is a helper method to define mock.On call
+//   - w http.ResponseWriter
+//   - r *http.Request
+//   - err error
+func (_e *Encoder_Expecter) Encode(w interface{}, r interface{}, err interface{}) *Encoder_Encode_Call {
+	return &Encoder_Encode_Call{Call: _e.mock.On("Encode", w, r, err)}
+}
+
+func (_c *Encoder_Encode_Call) Run(run func(w http.ResponseWriter, r *http.Request, err error)) *Encoder_Encode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(http.ResponseWriter), args[1].(*http.Request), args[2].(error))
+	})
+	return _c
+}
+
+func (_c *Encoder_Encode_Call) Return() *Encoder_Encode_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *Encoder_Encode_Call) RunAndReturn(run func(http.ResponseWriter, *http.Request, error)) *Encoder_Encode_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewEncoder creates a new instance of Encoder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEncoder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Encoder {
+	mock := &Encoder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
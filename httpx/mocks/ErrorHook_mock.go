@@ -0,0 +1,70 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package httpxmock
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ErrorHook is an autogenerated mock type for the ErrorHook type
+type ErrorHook struct {
+	mock.Mock
+}
+
+type ErrorHook_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ErrorHook) EXPECT() *ErrorHook_Expecter {
+	return &ErrorHook_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: r, err
+func (_m *ErrorHook) Execute(r *http.Request, err error) {
+	_m.Called(r, err)
+}
+
+// ErrorHook_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type ErrorHook_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - r *http.Request
+//   - err error
+func (_e *ErrorHook_Expecter) Execute(r interface{}, err interface{}) *ErrorHook_Execute_Call {
+	return &ErrorHook_Execute_Call{Call: _e.mock.On("Execute", r, err)}
+}
+
+func (_c *ErrorHook_Execute_Call) Run(run func(r *http.Request, err error)) *ErrorHook_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request), args[1].(error))
+	})
+	return _c
+}
+
+func (_c *ErrorHook_Execute_Call) Return() *ErrorHook_Execute_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *ErrorHook_Execute_Call) RunAndReturn(run func(*http.Request, error)) *ErrorHook_Execute_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewErrorHook creates a new instance of ErrorHook. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewErrorHook(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ErrorHook {
+	mock := &ErrorHook{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,297 @@
+package errorsx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// Kind classifies a failure independently of its message, so that callers
+// can decide how to react to it, such as whether to retry.
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindInvalid
+	KindNotFound
+	KindConflict
+	KindUnauthorized
+	KindForbidden
+	KindRateLimited
+	KindCanceled
+	KindTimeout
+	KindUnavailable
+	KindInternal
+)
+
+var kindNames = [...]string{
+	KindUnknown:      "unknown",
+	KindInvalid:      "invalid",
+	KindNotFound:     "not_found",
+	KindConflict:     "conflict",
+	KindUnauthorized: "unauthorized",
+	KindForbidden:    "forbidden",
+	KindRateLimited:  "rate_limited",
+	KindCanceled:     "canceled",
+	KindTimeout:      "timeout",
+	KindUnavailable:  "unavailable",
+	KindInternal:     "internal",
+}
+
+var kindStatuses = [...]int{
+	KindUnknown:      http.StatusInternalServerError,
+	KindInvalid:      http.StatusBadRequest,
+	KindNotFound:     http.StatusNotFound,
+	KindConflict:     http.StatusConflict,
+	KindUnauthorized: http.StatusUnauthorized,
+	KindForbidden:    http.StatusForbidden,
+	KindRateLimited:  http.StatusTooManyRequests,
+	KindCanceled:     499,
+	KindTimeout:      http.StatusGatewayTimeout,
+	KindUnavailable:  http.StatusServiceUnavailable,
+	KindInternal:     http.StatusInternalServerError,
+}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return kindNames[KindUnknown]
+	}
+
+	return kindNames[k]
+}
+
+// HTTPStatus returns the default HTTP status of failures of kind k.
+func (k Kind) HTTPStatus() int {
+	if k < 0 || int(k) >= len(kindStatuses) {
+		return kindStatuses[KindUnknown]
+	}
+
+	return kindStatuses[k]
+}
+
+// Retryable reports whether failures of kind k are transient: timeouts,
+// unavailable dependencies and rate limiting.
+func (k Kind) Retryable() bool {
+	return k == KindTimeout || k == KindUnavailable || k == KindRateLimited
+}
+
+// ParseKind returns the Kind named s, as returned by Kind.String.
+func ParseKind(s string) (Kind, bool) {
+	for k, name := range kindNames {
+		if name == s {
+			return Kind(k), true
+		}
+	}
+
+	return KindUnknown, false
+}
+
+// KindFromHTTPStatus classifies a failure from its HTTP status.
+func KindFromHTTPStatus(status int) Kind {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return KindInvalid
+	case http.StatusNotFound:
+		return KindNotFound
+	case http.StatusConflict:
+		return KindConflict
+	case http.StatusUnauthorized:
+		return KindUnauthorized
+	case http.StatusForbidden:
+		return KindForbidden
+	case http.StatusTooManyRequests:
+		return KindRateLimited
+	case 499:
+		return KindCanceled
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return KindTimeout
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return KindUnavailable
+	}
+
+	if status >= 500 {
+		return KindInternal
+	}
+
+	return KindUnknown
+}
+
+// NewKind creates an error of kind k carrying the default HTTP status of k.
+func NewKind(k Kind, message string, opts ...Option) ErrorX {
+	return wrapKind(k, newf(nil, opts, "%s", message))
+}
+
+// NewKindf creates an error of kind k with a formatted message, carrying the
+// default HTTP status of k.
+func NewKindf(k Kind, format string, args ...any) ErrorX {
+	return wrapKind(k, newf(nil, nil, format, args...))
+}
+
+// NewKindWithError creates an error of kind k that wraps err, carrying the
+// default HTTP status of k.
+func NewKindWithError(err error, k Kind, message string, opts ...Option) ErrorX {
+	return wrapKind(k, newf(err, opts, "%s", message))
+}
+
+// NewKindWithErrorf creates an error of kind k with a formatted message that
+// wraps err, carrying the default HTTP status of k.
+func NewKindWithErrorf(err error, k Kind, format string, args ...any) ErrorX {
+	return wrapKind(k, newf(err, nil, format, args...))
+}
+
+func wrapKind(k Kind, e ErrorX) ErrorX {
+	return &kindErrorX{
+		ErrorX: &httpErrorX{ErrorX: e, status: k.HTTPStatus()},
+		kind:   k,
+	}
+}
+
+// ErrorKinder is implemented by errors that carry a Kind.
+type ErrorKinder interface {
+	ErrorKind() Kind
+}
+
+type kindErrorX struct {
+	ErrorX
+
+	kind Kind
+}
+
+var _ ErrorKinder = (*kindErrorX)(nil)
+
+func (e *kindErrorX) Error() string {
+	return stringify(e)
+}
+
+func (e *kindErrorX) Unwrap() error {
+	return e.Inner()
+}
+
+func (e kindErrorX) Wrap(err error) ErrorX {
+	e.ErrorX = e.ErrorX.Wrap(err)
+	return &e
+}
+
+func (e *kindErrorX) With(args ...any) ErrorX {
+	return With(e, args...)
+}
+
+func (e *kindErrorX) ErrorKind() Kind {
+	return e.kind
+}
+
+func (e *kindErrorX) LayerMessage() string {
+	return "kind " + e.kind.String()
+}
+
+func (e *kindErrorX) Fields(fields ...string) map[string]any {
+	return mapify(e, fields)
+}
+
+func (e kindErrorX) Inner() ErrorX {
+	return e.ErrorX
+}
+
+func (e *kindErrorX) LayerFields() map[string]any {
+	return map[string]any{"kind": e.kind.String()}
+}
+
+func (e *kindErrorX) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *kindErrorX) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *kindErrorX) MarshalText() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *kindErrorX) UnmarshalText(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *kindErrorX) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(e)
+}
+
+func (e *kindErrorX) UnmarshalBinary(data []byte) error {
+	return unmarshalInto(e, DecodeBinary, data)
+}
+
+func (e *kindErrorX) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+func (e *kindErrorX) LogValue() slog.Value {
+	return logValue(e)
+}
+
+func (e *kindErrorX) encodeLayer() jsonLayer {
+	return jsonLayer{Type: layerTypeKind, Kind: e.kind.String()}
+}
+
+// ErrorKind classifies err. It returns the Kind of the outermost ErrorKinder
+// found in the chain of err, including errors.Join branches. Otherwise it
+// recognizes context errors, net.Error timeouts and connection failures, and
+// then falls back to the HTTP status of the chain. It reports false when err
+// can't be classified.
+func ErrorKind(err error) (Kind, bool) {
+	var (
+		kind  Kind
+		found bool
+	)
+
+	walk(err, func(e error) bool {
+		if ek, ok := e.(ErrorKinder); ok {
+			kind, found = ek.ErrorKind(), true
+			return false
+		}
+		return true
+	})
+
+	if found {
+		return kind, true
+	}
+
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return KindUnknown, false
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindTimeout, true
+	case errors.Is(err, context.Canceled):
+		return KindCanceled, true
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return KindTimeout, true
+	case errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNABORTED),
+		errors.Is(err, syscall.EPIPE):
+		return KindUnavailable, true
+	}
+
+	if status, ok := HTTPStatus(err); ok {
+		return KindFromHTTPStatus(status), true
+	}
+
+	return KindUnknown, false
+}
+
+// IsKind reports whether err is classified as k by ErrorKind.
+func IsKind(err error, k Kind) bool {
+	kind, ok := ErrorKind(err)
+	return ok && kind == k
+}
+
+// IsRetryable reports whether err is classified by ErrorKind as a transient
+// failure worth retrying.
+func IsRetryable(err error) bool {
+	kind, ok := ErrorKind(err)
+	return ok && kind.Retryable()
+}
@@ -0,0 +1,185 @@
+package errorsx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindErrorX_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NewKind", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewKind(errorsx.KindNotFound, "foo")
+		assert.Regexp(t, callerRX("foo: kind not_found: status 404"), errX.Error())
+		assert.Equal(t, map[string]any{
+			"message": "foo",
+			"kind":    "not_found",
+			"status":  http.StatusNotFound,
+		}, errX.Fields("message", "kind", "status"))
+	})
+
+	t.Run("NewKindf", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewKindf(errorsx.KindConflict, "user %d exists", 42)
+		assert.Regexp(t, callerRX("user 42 exists: kind conflict: status 409"), errX.Error())
+	})
+
+	t.Run("NewKindWithError", func(t *testing.T) {
+		t.Parallel()
+		err := fmt.Errorf("fake error")
+		errX := errorsx.NewKindWithError(err, errorsx.KindUnavailable, "foo")
+		assert.Regexp(t, callerRX("foo: fake error: kind unavailable: status 503"), errX.Error())
+		assert.ErrorIs(t, errX, err)
+	})
+
+	t.Run("NewKindWithErrorf", func(t *testing.T) {
+		t.Parallel()
+		err := fmt.Errorf("fake error")
+		errX := errorsx.NewKindWithErrorf(err, errorsx.KindTimeout, "call %s", "foo")
+		assert.Regexp(t, callerRX("call foo: fake error: kind timeout: status 504"), errX.Error())
+	})
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestErrorKind(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		err       error
+		want      errorsx.Kind
+		wantOK    bool
+		retryable bool
+	}{
+		{name: "nil", err: nil, want: errorsx.KindUnknown},
+		{name: "plain error", err: errors.New("foo"), want: errorsx.KindUnknown},
+		{
+			name:   "kind",
+			err:    errorsx.NewKind(errorsx.KindNotFound, "foo"),
+			want:   errorsx.KindNotFound,
+			wantOK: true,
+		},
+		{
+			name:      "wrapped kind",
+			err:       fmt.Errorf("bar: %w", errorsx.NewKind(errorsx.KindRateLimited, "foo")),
+			want:      errorsx.KindRateLimited,
+			wantOK:    true,
+			retryable: true,
+		},
+		{
+			name:      "outermost kind",
+			err:       errorsx.NewKindWithError(context.Canceled, errorsx.KindUnavailable, "foo"),
+			want:      errorsx.KindUnavailable,
+			wantOK:    true,
+			retryable: true,
+		},
+		{
+			name:      "context.DeadlineExceeded",
+			err:       errorsx.NewWithError(context.DeadlineExceeded, "foo"),
+			want:      errorsx.KindTimeout,
+			wantOK:    true,
+			retryable: true,
+		},
+		{
+			name:   "context.Canceled",
+			err:    fmt.Errorf("foo: %w", context.Canceled),
+			want:   errorsx.KindCanceled,
+			wantOK: true,
+		},
+		{
+			name:      "net.Error timeout",
+			err:       &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}},
+			want:      errorsx.KindTimeout,
+			wantOK:    true,
+			retryable: true,
+		},
+		{
+			name:      "os.ErrDeadlineExceeded",
+			err:       fmt.Errorf("foo: %w", os.ErrDeadlineExceeded),
+			want:      errorsx.KindTimeout,
+			wantOK:    true,
+			retryable: true,
+		},
+		{
+			name:      "ECONNRESET",
+			err:       &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)},
+			want:      errorsx.KindUnavailable,
+			wantOK:    true,
+			retryable: true,
+		},
+		{
+			name:   "HTTP status",
+			err:    errorsx.NewHTTP(http.StatusForbidden, "foo"),
+			want:   errorsx.KindForbidden,
+			wantOK: true,
+		},
+		{
+			name:      "retryable HTTP status",
+			err:       errorsx.NewHTTP(http.StatusBadGateway, "foo"),
+			want:      errorsx.KindUnavailable,
+			wantOK:    true,
+			retryable: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := errorsx.ErrorKind(tc.err)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantOK, errorsx.IsKind(tc.err, tc.want))
+			assert.Equal(t, tc.retryable, errorsx.IsRetryable(tc.err))
+		})
+	}
+}
+
+func TestKind(t *testing.T) {
+	t.Parallel()
+
+	for k := errorsx.KindUnknown; k <= errorsx.KindInternal; k++ {
+		got, ok := errorsx.ParseKind(k.String())
+		assert.True(t, ok)
+		assert.Equal(t, k, got)
+		if k != errorsx.KindUnknown {
+			assert.Equal(t, k, errorsx.KindFromHTTPStatus(k.HTTPStatus()), k.String())
+		}
+	}
+
+	assert.Equal(t, "unknown", errorsx.Kind(-1).String())
+	assert.Equal(t, http.StatusInternalServerError, errorsx.Kind(100).HTTPStatus())
+
+	_, ok := errorsx.ParseKind("foo")
+	assert.False(t, ok)
+}
+
+func TestKindErrorX_DecodeJSON(t *testing.T) {
+	t.Parallel()
+	errX := errorsx.NewKind(errorsx.KindUnavailable, "foo")
+
+	data, err := errorsx.EncodeJSON(errX)
+	require.NoError(t, err)
+
+	got, err := errorsx.DecodeJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, errX.Error(), got.Error())
+	assert.True(t, errorsx.IsKind(got, errorsx.KindUnavailable))
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package errorsxmock
+
+import mock "github.com/stretchr/testify/mock"
+
+// Attributer is an autogenerated mock type for the Attributer type
+type Attributer struct {
+	mock.Mock
+}
+
+type Attributer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Attributer) EXPECT() *Attributer_Expecter {
+	return &Attributer_Expecter{mock: &_m.Mock}
+}
+
+// Attributes provides a mock function with no fields
+func (_m *Attributer) Attributes() map[string]any {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Attributes")
+	}
+
+	var r0 map[string]any
+	if rf, ok := ret.Get(0).(func() map[string]any); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]any)
+		}
+	}
+
+	return r0
+}
+
+// Attributer_Attributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Attributes'
+type Attributer_Attributes_Call struct {
+	*mock.Call
+}
+
+// Attributes is a helper method to define mock.On call
+func (_e *Attributer_Expecter) Attributes() *Attributer_Attributes_Call {
+	return &Attributer_Attributes_Call{Call: _e.mock.On("Attributes")}
+}
+
+func (_c *Attributer_Attributes_Call) Run(run func()) *Attributer_Attributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Attributer_Attributes_Call) Return(_a0 map[string]any) *Attributer_Attributes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Attributer_Attributes_Call) RunAndReturn(run func() map[string]any) *Attributer_Attributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAttributer creates a new instance of Attributer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttributer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Attributer {
+	mock := &Attributer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
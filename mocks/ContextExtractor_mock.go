@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package errorsxmock
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ContextExtractor is an autogenerated mock type for the ContextExtractor type
+type ContextExtractor struct {
+	mock.Mock
+}
+
+type ContextExtractor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ContextExtractor) EXPECT() *ContextExtractor_Expecter {
+	return &ContextExtractor_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx
+func (_m *ContextExtractor) Execute(ctx context.Context) map[string]any {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 map[string]any
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]any); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]any)
+		}
+	}
+
+	return r0
+}
+
+// ContextExtractor_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type ContextExtractor_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContextExtractor_Expecter) Execute(ctx interface{}) *ContextExtractor_Execute_Call {
+	return &ContextExtractor_Execute_Call{Call: _e.mock.On("Execute", ctx)}
+}
+
+func (_c *ContextExtractor_Execute_Call) Run(run func(ctx context.Context)) *ContextExtractor_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContextExtractor_Execute_Call) Return(_a0 map[string]any) *ContextExtractor_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContextExtractor_Execute_Call) RunAndReturn(run func(context.Context) map[string]any) *ContextExtractor_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewContextExtractor creates a new instance of ContextExtractor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContextExtractor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContextExtractor {
+	mock := &ContextExtractor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
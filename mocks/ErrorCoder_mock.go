@@ -0,0 +1,82 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package errorsxmock
+
+import (
+	errorsx "github.com/caioreix/errorsx"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ErrorCoder is an autogenerated mock type for the ErrorCoder type
+type ErrorCoder struct {
+	mock.Mock
+}
+
+type ErrorCoder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ErrorCoder) EXPECT() *ErrorCoder_Expecter {
+	return &ErrorCoder_Expecter{mock: &_m.Mock}
+}
+
+// ErrorCode provides a mock function with no fields
+func (_m *ErrorCoder) ErrorCode() *errorsx.Code {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ErrorCode")
+	}
+
+	var r0 *errorsx.Code
+	if rf, ok := ret.Get(0).(func() *errorsx.Code); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errorsx.Code)
+		}
+	}
+
+	return r0
+}
+
+// ErrorCoder_ErrorCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ErrorCode'
+type ErrorCoder_ErrorCode_Call struct {
+	*mock.Call
+}
+
+// ErrorCode is a helper method to define mock.On call
+func (_e *ErrorCoder_Expecter) ErrorCode() *ErrorCoder_ErrorCode_Call {
+	return &ErrorCoder_ErrorCode_Call{Call: _e.mock.On("ErrorCode")}
+}
+
+func (_c *ErrorCoder_ErrorCode_Call) Run(run func()) *ErrorCoder_ErrorCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ErrorCoder_ErrorCode_Call) Return(_a0 *errorsx.Code) *ErrorCoder_ErrorCode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ErrorCoder_ErrorCode_Call) RunAndReturn(run func() *errorsx.Code) *ErrorCoder_ErrorCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewErrorCoder creates a new instance of ErrorCoder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewErrorCoder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ErrorCoder {
+	mock := &ErrorCoder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,80 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package errorsxmock
+
+import (
+	errorsx "github.com/caioreix/errorsx"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ErrorKinder is an autogenerated mock type for the ErrorKinder type
+type ErrorKinder struct {
+	mock.Mock
+}
+
+type ErrorKinder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ErrorKinder) EXPECT() *ErrorKinder_Expecter {
+	return &ErrorKinder_Expecter{mock: &_m.Mock}
+}
+
+// ErrorKind provides a mock function with no fields
+func (_m *ErrorKinder) ErrorKind() errorsx.Kind {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ErrorKind")
+	}
+
+	var r0 errorsx.Kind
+	if rf, ok := ret.Get(0).(func() errorsx.Kind); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(errorsx.Kind)
+	}
+
+	return r0
+}
+
+// ErrorKinder_ErrorKind_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ErrorKind'
+type ErrorKinder_ErrorKind_Call struct {
+	*mock.Call
+}
+
+// ErrorKind is a helper method to define mock.On call
+func (_e *ErrorKinder_Expecter) ErrorKind() *ErrorKinder_ErrorKind_Call {
+	return &ErrorKinder_ErrorKind_Call{Call: _e.mock.On("ErrorKind")}
+}
+
+func (_c *ErrorKinder_ErrorKind_Call) Run(run func()) *ErrorKinder_ErrorKind_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ErrorKinder_ErrorKind_Call) Return(_a0 errorsx.Kind) *ErrorKinder_ErrorKind_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ErrorKinder_ErrorKind_Call) RunAndReturn(run func() errorsx.Kind) *ErrorKinder_ErrorKind_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewErrorKinder creates a new instance of ErrorKinder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewErrorKinder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ErrorKinder {
+	mock := &ErrorKinder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
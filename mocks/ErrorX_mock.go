@@ -171,249 +171,250 @@ func (_c *ErrorX_Fields_Call) RunAndReturn(run func(...string) map[string]any) *
 	return _c
 }
 
-// Stack provides a mock function with no fields
-func (_m *ErrorX) Stack() errorsx.Stack {
+// Inner provides a mock function with no fields
+func (_m *ErrorX) Inner() errorsx.ErrorX {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for Stack")
+		panic("no return value specified for Inner")
 	}
 
-	var r0 errorsx.Stack
-	if rf, ok := ret.Get(0).(func() errorsx.Stack); ok {
+	var r0 errorsx.ErrorX
+	if rf, ok := ret.Get(0).(func() errorsx.ErrorX); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errorsx.Stack)
+			r0 = ret.Get(0).(errorsx.ErrorX)
 		}
 	}
 
 	return r0
 }
 
-// ErrorX_Stack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stack'
-type ErrorX_Stack_Call struct {
+// ErrorX_Inner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Inner'
+type ErrorX_Inner_Call struct {
 	*mock.Call
 }
 
-// Stack is a helper method to define mock.On call
-func (_e *ErrorX_Expecter) Stack() *ErrorX_Stack_Call {
-	return &ErrorX_Stack_Call{Call: _e.mock.On("Stack")}
+// Inner is a helper method to define mock.On call
+func (_e *ErrorX_Expecter) Inner() *ErrorX_Inner_Call {
+	return &ErrorX_Inner_Call{Call: _e.mock.On("Inner")}
 }
 
-func (_c *ErrorX_Stack_Call) Run(run func()) *ErrorX_Stack_Call {
+func (_c *ErrorX_Inner_Call) Run(run func()) *ErrorX_Inner_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *ErrorX_Stack_Call) Return(_a0 errorsx.Stack) *ErrorX_Stack_Call {
+func (_c *ErrorX_Inner_Call) Return(_a0 errorsx.ErrorX) *ErrorX_Inner_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ErrorX_Stack_Call) RunAndReturn(run func() errorsx.Stack) *ErrorX_Stack_Call {
+func (_c *ErrorX_Inner_Call) RunAndReturn(run func() errorsx.ErrorX) *ErrorX_Inner_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Unwrap provides a mock function with no fields
-func (_m *ErrorX) Unwrap() error {
+// LayerFields provides a mock function with no fields
+func (_m *ErrorX) LayerFields() map[string]any {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for Unwrap")
+		panic("no return value specified for LayerFields")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
+	var r0 map[string]any
+	if rf, ok := ret.Get(0).(func() map[string]any); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]any)
+		}
 	}
 
 	return r0
 }
 
-// ErrorX_Unwrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unwrap'
-type ErrorX_Unwrap_Call struct {
+// ErrorX_LayerFields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LayerFields'
+type ErrorX_LayerFields_Call struct {
 	*mock.Call
 }
 
-// Unwrap is a helper method to define mock.On call
-func (_e *ErrorX_Expecter) Unwrap() *ErrorX_Unwrap_Call {
-	return &ErrorX_Unwrap_Call{Call: _e.mock.On("Unwrap")}
+// LayerFields is a helper method to define mock.On call
+func (_e *ErrorX_Expecter) LayerFields() *ErrorX_LayerFields_Call {
+	return &ErrorX_LayerFields_Call{Call: _e.mock.On("LayerFields")}
 }
 
-func (_c *ErrorX_Unwrap_Call) Run(run func()) *ErrorX_Unwrap_Call {
+func (_c *ErrorX_LayerFields_Call) Run(run func()) *ErrorX_LayerFields_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *ErrorX_Unwrap_Call) Return(_a0 error) *ErrorX_Unwrap_Call {
+func (_c *ErrorX_LayerFields_Call) Return(_a0 map[string]any) *ErrorX_LayerFields_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ErrorX_Unwrap_Call) RunAndReturn(run func() error) *ErrorX_Unwrap_Call {
+func (_c *ErrorX_LayerFields_Call) RunAndReturn(run func() map[string]any) *ErrorX_LayerFields_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Wrap provides a mock function with given fields: err
-func (_m *ErrorX) Wrap(err error) errorsx.ErrorX {
-	ret := _m.Called(err)
+// LayerMessage provides a mock function with no fields
+func (_m *ErrorX) LayerMessage() string {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for Wrap")
+		panic("no return value specified for LayerMessage")
 	}
 
-	var r0 errorsx.ErrorX
-	if rf, ok := ret.Get(0).(func(error) errorsx.ErrorX); ok {
-		r0 = rf(err)
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errorsx.ErrorX)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
 	return r0
 }
 
-// ErrorX_Wrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Wrap'
-type ErrorX_Wrap_Call struct {
+// ErrorX_LayerMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LayerMessage'
+type ErrorX_LayerMessage_Call struct {
 	*mock.Call
 }
 
-// Wrap is a helper method to define mock.On call
-//   - err error
-func (_e *ErrorX_Expecter) Wrap(err interface{}) *ErrorX_Wrap_Call {
-	return &ErrorX_Wrap_Call{Call: _e.mock.On("Wrap", err)}
+// LayerMessage is a helper method to define mock.On call
+func (_e *ErrorX_Expecter) LayerMessage() *ErrorX_LayerMessage_Call {
+	return &ErrorX_LayerMessage_Call{Call: _e.mock.On("LayerMessage")}
 }
 
-func (_c *ErrorX_Wrap_Call) Run(run func(err error)) *ErrorX_Wrap_Call {
+func (_c *ErrorX_LayerMessage_Call) Run(run func()) *ErrorX_LayerMessage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(error))
+		run()
 	})
 	return _c
 }
 
-func (_c *ErrorX_Wrap_Call) Return(_a0 errorsx.ErrorX) *ErrorX_Wrap_Call {
+func (_c *ErrorX_LayerMessage_Call) Return(_a0 string) *ErrorX_LayerMessage_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ErrorX_Wrap_Call) RunAndReturn(run func(error) errorsx.ErrorX) *ErrorX_Wrap_Call {
+func (_c *ErrorX_LayerMessage_Call) RunAndReturn(run func() string) *ErrorX_LayerMessage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// fields provides a mock function with no fields
-func (_m *ErrorX) fields() map[string]any {
+// Stack provides a mock function with no fields
+func (_m *ErrorX) Stack() errorsx.Stack {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for fields")
+		panic("no return value specified for Stack")
 	}
 
-	var r0 map[string]any
-	if rf, ok := ret.Get(0).(func() map[string]any); ok {
+	var r0 errorsx.Stack
+	if rf, ok := ret.Get(0).(func() errorsx.Stack); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]any)
+			r0 = ret.Get(0).(errorsx.Stack)
 		}
 	}
 
 	return r0
 }
 
-// ErrorX_fields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'fields'
-type ErrorX_fields_Call struct {
+// ErrorX_Stack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stack'
+type ErrorX_Stack_Call struct {
 	*mock.Call
 }
 
-// fields is a helper method to define mock.On call
-func (_e *ErrorX_Expecter) fields() *ErrorX_fields_Call {
-	return &ErrorX_fields_Call{Call: _e.mock.On("fields")}
+// Stack is a helper method to define mock.On call
+func (_e *ErrorX_Expecter) Stack() *ErrorX_Stack_Call {
+	return &ErrorX_Stack_Call{Call: _e.mock.On("Stack")}
 }
 
-func (_c *ErrorX_fields_Call) Run(run func()) *ErrorX_fields_Call {
+func (_c *ErrorX_Stack_Call) Run(run func()) *ErrorX_Stack_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *ErrorX_fields_Call) Return(_a0 map[string]any) *ErrorX_fields_Call {
+func (_c *ErrorX_Stack_Call) Return(_a0 errorsx.Stack) *ErrorX_Stack_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ErrorX_fields_Call) RunAndReturn(run func() map[string]any) *ErrorX_fields_Call {
+func (_c *ErrorX_Stack_Call) RunAndReturn(run func() errorsx.Stack) *ErrorX_Stack_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// string provides a mock function with no fields
-func (_m *ErrorX) string() string {
+// Unwrap provides a mock function with no fields
+func (_m *ErrorX) Unwrap() error {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for string")
+		panic("no return value specified for Unwrap")
 	}
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// ErrorX_string_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'string'
-type ErrorX_string_Call struct {
+// ErrorX_Unwrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unwrap'
+type ErrorX_Unwrap_Call struct {
 	*mock.Call
 }
 
-// string is a helper method to define mock.On call
-func (_e *ErrorX_Expecter) string() *ErrorX_string_Call {
-	return &ErrorX_string_Call{Call: _e.mock.On("string")}
+// Unwrap is a helper method to define mock.On call
+func (_e *ErrorX_Expecter) Unwrap() *ErrorX_Unwrap_Call {
+	return &ErrorX_Unwrap_Call{Call: _e.mock.On("Unwrap")}
 }
 
-func (_c *ErrorX_string_Call) Run(run func()) *ErrorX_string_Call {
+func (_c *ErrorX_Unwrap_Call) Run(run func()) *ErrorX_Unwrap_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *ErrorX_string_Call) Return(_a0 string) *ErrorX_string_Call {
+func (_c *ErrorX_Unwrap_Call) Return(_a0 error) *ErrorX_Unwrap_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ErrorX_string_Call) RunAndReturn(run func() string) *ErrorX_string_Call {
+func (_c *ErrorX_Unwrap_Call) RunAndReturn(run func() error) *ErrorX_Unwrap_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// unwrap provides a mock function with no fields
-func (_m *ErrorX) unwrap() errorsx.ErrorX {
-	ret := _m.Called()
+// With provides a mock function with given fields: args
+func (_m *ErrorX) With(args ...any) errorsx.ErrorX {
+	var _ca []interface{}
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for unwrap")
+		panic("no return value specified for With")
 	}
 
 	var r0 errorsx.ErrorX
-	if rf, ok := ret.Get(0).(func() errorsx.ErrorX); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(...any) errorsx.ErrorX); ok {
+		r0 = rf(args...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errorsx.ErrorX)
@@ -423,29 +424,85 @@ func (_m *ErrorX) unwrap() errorsx.ErrorX {
 	return r0
 }
 
-// ErrorX_unwrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'unwrap'
-type ErrorX_unwrap_Call struct {
+// ErrorX_With_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'With'
+type ErrorX_With_Call struct {
 	*mock.Call
 }
 
-// unwrap is a helper method to define mock.On call
-func (_e *ErrorX_Expecter) unwrap() *ErrorX_unwrap_Call {
-	return &ErrorX_unwrap_Call{Call: _e.mock.On("unwrap")}
+// With is a helper method to define mock.On call
+//   - args ...any
+func (_e *ErrorX_Expecter) With(args ...interface{}) *ErrorX_With_Call {
+	return &ErrorX_With_Call{Call: _e.mock.On("With",
+		append([]interface{}{}, args...)...)}
 }
 
-func (_c *ErrorX_unwrap_Call) Run(run func()) *ErrorX_unwrap_Call {
+func (_c *ErrorX_With_Call) Run(run func(args ...any)) *ErrorX_With_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		variadicArgs := make([]any, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(any)
+			}
+		}
+		run(variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *ErrorX_unwrap_Call) Return(_a0 errorsx.ErrorX) *ErrorX_unwrap_Call {
+func (_c *ErrorX_With_Call) Return(_a0 errorsx.ErrorX) *ErrorX_With_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *ErrorX_unwrap_Call) RunAndReturn(run func() errorsx.ErrorX) *ErrorX_unwrap_Call {
+func (_c *ErrorX_With_Call) RunAndReturn(run func(...any) errorsx.ErrorX) *ErrorX_With_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Wrap provides a mock function with given fields: err
+func (_m *ErrorX) Wrap(err error) errorsx.ErrorX {
+	ret := _m.Called(err)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Wrap")
+	}
+
+	var r0 errorsx.ErrorX
+	if rf, ok := ret.Get(0).(func(error) errorsx.ErrorX); ok {
+		r0 = rf(err)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errorsx.ErrorX)
+		}
+	}
+
+	return r0
+}
+
+// ErrorX_Wrap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Wrap'
+type ErrorX_Wrap_Call struct {
+	*mock.Call
+}
+
+// Wrap is a helper method to define mock.On call
+//   - err error
+func (_e *ErrorX_Expecter) Wrap(err interface{}) *ErrorX_Wrap_Call {
+	return &ErrorX_Wrap_Call{Call: _e.mock.On("Wrap", err)}
+}
+
+func (_c *ErrorX_Wrap_Call) Run(run func(err error)) *ErrorX_Wrap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(error))
+	})
+	return _c
+}
+
+func (_c *ErrorX_Wrap_Call) Return(_a0 errorsx.ErrorX) *ErrorX_Wrap_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ErrorX_Wrap_Call) RunAndReturn(run func(error) errorsx.ErrorX) *ErrorX_Wrap_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,82 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package errorsxmock
+
+import (
+	validator "github.com/go-playground/validator/v10"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FieldErrorer is an autogenerated mock type for the FieldErrorer type
+type FieldErrorer struct {
+	mock.Mock
+}
+
+type FieldErrorer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FieldErrorer) EXPECT() *FieldErrorer_Expecter {
+	return &FieldErrorer_Expecter{mock: &_m.Mock}
+}
+
+// FieldErrors provides a mock function with no fields
+func (_m *FieldErrorer) FieldErrors() validator.ValidationErrors {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FieldErrors")
+	}
+
+	var r0 validator.ValidationErrors
+	if rf, ok := ret.Get(0).(func() validator.ValidationErrors); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(validator.ValidationErrors)
+		}
+	}
+
+	return r0
+}
+
+// FieldErrorer_FieldErrors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FieldErrors'
+type FieldErrorer_FieldErrors_Call struct {
+	*mock.Call
+}
+
+// FieldErrors is a helper method to define mock.On call
+func (_e *FieldErrorer_Expecter) FieldErrors() *FieldErrorer_FieldErrors_Call {
+	return &FieldErrorer_FieldErrors_Call{Call: _e.mock.On("FieldErrors")}
+}
+
+func (_c *FieldErrorer_FieldErrors_Call) Run(run func()) *FieldErrorer_FieldErrors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *FieldErrorer_FieldErrors_Call) Return(_a0 validator.ValidationErrors) *FieldErrorer_FieldErrors_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FieldErrorer_FieldErrors_Call) RunAndReturn(run func() validator.ValidationErrors) *FieldErrorer_FieldErrors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFieldErrorer creates a new instance of FieldErrorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFieldErrorer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FieldErrorer {
+	mock := &FieldErrorer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
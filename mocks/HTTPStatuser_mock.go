@@ -0,0 +1,77 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package errorsxmock
+
+import mock "github.com/stretchr/testify/mock"
+
+// HTTPStatuser is an autogenerated mock type for the HTTPStatuser type
+type HTTPStatuser struct {
+	mock.Mock
+}
+
+type HTTPStatuser_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HTTPStatuser) EXPECT() *HTTPStatuser_Expecter {
+	return &HTTPStatuser_Expecter{mock: &_m.Mock}
+}
+
+// HTTPStatus provides a mock function with no fields
+func (_m *HTTPStatuser) HTTPStatus() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HTTPStatus")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// HTTPStatuser_HTTPStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HTTPStatus'
+type HTTPStatuser_HTTPStatus_Call struct {
+	*mock.Call
+}
+
+// HTTPStatus is a helper method to define mock.On call
+func (_e *HTTPStatuser_Expecter) HTTPStatus() *HTTPStatuser_HTTPStatus_Call {
+	return &HTTPStatuser_HTTPStatus_Call{Call: _e.mock.On("HTTPStatus")}
+}
+
+func (_c *HTTPStatuser_HTTPStatus_Call) Run(run func()) *HTTPStatuser_HTTPStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HTTPStatuser_HTTPStatus_Call) Return(_a0 int) *HTTPStatuser_HTTPStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HTTPStatuser_HTTPStatus_Call) RunAndReturn(run func() int) *HTTPStatuser_HTTPStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHTTPStatuser creates a new instance of HTTPStatuser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHTTPStatuser(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HTTPStatuser {
+	mock := &HTTPStatuser{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
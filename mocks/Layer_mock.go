@@ -0,0 +1,174 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package errorsxmock
+
+import (
+	errorsx "github.com/caioreix/errorsx"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Layer is an autogenerated mock type for the Layer type
+type Layer struct {
+	mock.Mock
+}
+
+type Layer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Layer) EXPECT() *Layer_Expecter {
+	return &Layer_Expecter{mock: &_m.Mock}
+}
+
+// Inner provides a mock function with no fields
+func (_m *Layer) Inner() errorsx.ErrorX {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inner")
+	}
+
+	var r0 errorsx.ErrorX
+	if rf, ok := ret.Get(0).(func() errorsx.ErrorX); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errorsx.ErrorX)
+		}
+	}
+
+	return r0
+}
+
+// Layer_Inner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Inner'
+type Layer_Inner_Call struct {
+	*mock.Call
+}
+
+// Inner is a helper method to define mock.On call
+func (_e *Layer_Expecter) Inner() *Layer_Inner_Call {
+	return &Layer_Inner_Call{Call: _e.mock.On("Inner")}
+}
+
+func (_c *Layer_Inner_Call) Run(run func()) *Layer_Inner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Layer_Inner_Call) Return(_a0 errorsx.ErrorX) *Layer_Inner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Layer_Inner_Call) RunAndReturn(run func() errorsx.ErrorX) *Layer_Inner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LayerFields provides a mock function with no fields
+func (_m *Layer) LayerFields() map[string]any {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LayerFields")
+	}
+
+	var r0 map[string]any
+	if rf, ok := ret.Get(0).(func() map[string]any); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]any)
+		}
+	}
+
+	return r0
+}
+
+// Layer_LayerFields_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LayerFields'
+type Layer_LayerFields_Call struct {
+	*mock.Call
+}
+
+// LayerFields is a helper method to define mock.On call
+func (_e *Layer_Expecter) LayerFields() *Layer_LayerFields_Call {
+	return &Layer_LayerFields_Call{Call: _e.mock.On("LayerFields")}
+}
+
+func (_c *Layer_LayerFields_Call) Run(run func()) *Layer_LayerFields_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Layer_LayerFields_Call) Return(_a0 map[string]any) *Layer_LayerFields_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Layer_LayerFields_Call) RunAndReturn(run func() map[string]any) *Layer_LayerFields_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LayerMessage provides a mock function with no fields
+func (_m *Layer) LayerMessage() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LayerMessage")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Layer_LayerMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LayerMessage'
+type Layer_LayerMessage_Call struct {
+	*mock.Call
+}
+
+// LayerMessage is a helper method to define mock.On call
+func (_e *Layer_Expecter) LayerMessage() *Layer_LayerMessage_Call {
+	return &Layer_LayerMessage_Call{Call: _e.mock.On("LayerMessage")}
+}
+
+func (_c *Layer_LayerMessage_Call) Run(run func()) *Layer_LayerMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Layer_LayerMessage_Call) Return(_a0 string) *Layer_LayerMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Layer_LayerMessage_Call) RunAndReturn(run func() string) *Layer_LayerMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLayer creates a new instance of Layer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLayer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Layer {
+	mock := &Layer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package errorsxmock
+
+import mock "github.com/stretchr/testify/mock"
+
+// PublicMessager is an autogenerated mock type for the PublicMessager type
+type PublicMessager struct {
+	mock.Mock
+}
+
+type PublicMessager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PublicMessager) EXPECT() *PublicMessager_Expecter {
+	return &PublicMessager_Expecter{mock: &_m.Mock}
+}
+
+// PublicMessage provides a mock function with no fields
+func (_m *PublicMessager) PublicMessage() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublicMessage")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// PublicMessager_PublicMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublicMessage'
+type PublicMessager_PublicMessage_Call struct {
+	*mock.Call
+}
+
+// PublicMessage is a helper method to define mock.On call
+func (_e *PublicMessager_Expecter) PublicMessage() *PublicMessager_PublicMessage_Call {
+	return &PublicMessager_PublicMessage_Call{Call: _e.mock.On("PublicMessage")}
+}
+
+func (_c *PublicMessager_PublicMessage_Call) Run(run func()) *PublicMessager_PublicMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *PublicMessager_PublicMessage_Call) Return(_a0 string) *PublicMessager_PublicMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PublicMessager_PublicMessage_Call) RunAndReturn(run func() string) *PublicMessager_PublicMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPublicMessager creates a new instance of PublicMessager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPublicMessager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PublicMessager {
+	mock := &PublicMessager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+package errorsx
+
+import ut "github.com/go-playground/universal-translator"
+
+// Option overrides package-wide settings for a single constructor call.
+type Option func(*options)
+
+type options struct {
+	stackPolicy      *StackPolicy
+	callers          *callers
+	translator       ut.Translator
+	validationPolicy *ValidationPolicy
+	publicMessage    string
+	redactionPolicy  *RedactionPolicy
+	contextFields    map[string]any
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+func (o options) stack() StackPolicy {
+	if o.stackPolicy != nil {
+		return *o.stackPolicy
+	}
+
+	return GetStackPolicy()
+}
+
+func (o options) validation() validationOptions {
+	v := validationOptions{translator: o.translator, policy: GetValidationPolicy()}
+	if o.validationPolicy != nil {
+		v.policy = *o.validationPolicy
+	}
+
+	return v
+}
+
+// WithStackPolicy makes the error capture its stack following p instead of
+// the package-wide StackPolicy.
+func WithStackPolicy(p StackPolicy) Option {
+	return func(o *options) {
+		o.stackPolicy = &p
+	}
+}
+
+// WithStackMode overrides the Mode of the package-wide StackPolicy.
+func WithStackMode(mode StackMode) Option {
+	return func(o *options) {
+		p := o.stack()
+		p.Mode = mode
+		o.stackPolicy = &p
+	}
+}
+
+// WithStackDepth overrides the MaxDepth of the package-wide StackPolicy.
+func WithStackDepth(depth int) Option {
+	return func(o *options) {
+		p := o.stack()
+		p.MaxDepth = depth
+		o.stackPolicy = &p
+	}
+}
+
+// WithCallers makes the error report caller and stack instead of capturing
+// its own, such as to restore the ones of an error received from another
+// process.
+func WithCallers(caller string, stack Stack) Option {
+	return func(o *options) {
+		o.callers = resolvedCallers(caller, stack)
+	}
+}
+
+// WithValidationPolicy makes the error lay out its validation failures
+// following p instead of the package-wide ValidationPolicy.
+func WithValidationPolicy(p ValidationPolicy) Option {
+	return func(o *options) {
+		o.validationPolicy = &p
+	}
+}
@@ -0,0 +1,194 @@
+// Package otelx records errorsx errors on OpenTelemetry spans, keeping their
+// caller, stack, status and fields, and copies the IDs of the current span
+// into the errors created with a context.
+package otelx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/caioreix/errorsx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Keys of the OpenTelemetry exception semantic conventions.
+const (
+	ExceptionEventName  = "exception"
+	ExceptionType       = attribute.Key("exception.type")
+	ExceptionMessage    = attribute.Key("exception.message")
+	ExceptionStacktrace = attribute.Key("exception.stacktrace")
+)
+
+// TraceExtractorName is the name RegisterTraceExtractor registers
+// TraceContext under.
+const TraceExtractorName = "trace"
+
+// TraceContext is the errorsx.ContextExtractor copying the "trace_id" and
+// "span_id" of the span carried by ctx, when it is valid.
+func TraceContext(ctx context.Context) map[string]any {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return map[string]any{
+		"trace_id": sc.TraceID().String(),
+		"span_id":  sc.SpanID().String(),
+	}
+}
+
+// RegisterTraceExtractor registers TraceContext with errorsx under
+// TraceExtractorName, so the errors created with errorsx.NewCtx or
+// errorsx.WithContext carry the IDs of the current span.
+func RegisterTraceExtractor() {
+	errorsx.RegisterContextExtractor(TraceExtractorName, TraceContext)
+}
+
+// Option configures RecordError.
+type Option func(*config)
+
+type config struct {
+	clientErrors bool
+	eventOpts    []trace.EventOption
+}
+
+// WithClientErrors marks the span as failed for 4xx statuses too, as client
+// spans should. By default only 5xx statuses and errors without a status
+// fail the span, following the conventions for server spans.
+func WithClientErrors() Option {
+	return func(c *config) {
+		c.clientErrors = true
+	}
+}
+
+// WithEventOptions adds opts, such as trace.WithTimestamp, to the recorded
+// event.
+func WithEventOptions(opts ...trace.EventOption) Option {
+	return func(c *config) {
+		c.eventOpts = append(c.eventOpts, opts...)
+	}
+}
+
+// RecordError records err on span as an "exception" event and sets the
+// span status from the HTTP status of err. For an ErrorX, the event carries
+// the error code, or else the Go type, as exception.type, errorsx.Message,
+// which leaves out the callers, as exception.message, the filtered stack as
+// exception.stacktrace and every field but the stack as attributes, nested
+// maps flattened into dotted keys. Other errors are recorded the way
+// span.RecordError does.
+func RecordError(span trace.Span, err error, opts ...Option) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var ex errorsx.ErrorX
+	if !errors.As(err, &ex) {
+		span.RecordError(err, c.eventOpts...)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		ExceptionType.String(exceptionType(err)),
+		ExceptionMessage.String(errorsx.Message(err)),
+		ExceptionStacktrace.String(stacktrace(ex.Stack().Filter(errorsx.GetStackFilter()))),
+	}
+	attrs = append(attrs, Attributes(ex)...)
+
+	span.AddEvent(ExceptionEventName, append([]trace.EventOption{trace.WithAttributes(attrs...)}, c.eventOpts...)...)
+
+	status, ok := errorsx.HTTPStatus(err)
+	failed := !ok || status >= http.StatusInternalServerError ||
+		(c.clientErrors && status >= http.StatusBadRequest)
+	if failed {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// Attributes converts the fields of ex, but its stack, into attributes.
+// Nested maps are flattened into dotted keys, such as
+// "validation_errors.User.Name", and lists of maps are encoded as JSON.
+func Attributes(ex errorsx.ErrorX) []attribute.KeyValue {
+	fields := ex.Fields()
+	delete(fields, "stack")
+
+	var attrs []attribute.KeyValue
+	flatten(&attrs, "", fields)
+
+	return attrs
+}
+
+func flatten(attrs *[]attribute.KeyValue, prefix string, fields map[string]any) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		key := prefix + k
+		if nested, ok := fields[k].(map[string]any); ok {
+			flatten(attrs, key+".", nested)
+			continue
+		}
+		*attrs = append(*attrs, attributeOf(key, fields[k]))
+	}
+}
+
+func attributeOf(key string, v any) attribute.KeyValue {
+	switch v := v.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	case []string:
+		return attribute.StringSlice(key, v)
+	case fmt.Stringer:
+		return attribute.String(key, v.String())
+	case []map[string]any:
+		data, err := json.Marshal(v)
+		if err == nil {
+			return attribute.String(key, string(data))
+		}
+	}
+
+	return attribute.String(key, fmt.Sprint(v))
+}
+
+// exceptionType returns the error code of err, or else its Go type.
+func exceptionType(err error) string {
+	if c, ok := errorsx.ErrorCode(err); ok {
+		return c.Code
+	}
+
+	return fmt.Sprintf("%T", err)
+}
+
+// stacktrace renders stack the way runtime/debug.Stack lays out frames.
+func stacktrace(stack errorsx.Stack) string {
+	var b strings.Builder
+	for _, sf := range stack {
+		b.WriteString(sf.Function + "\n\t" + sf.File + ":" + strconv.Itoa(sf.Line) + "\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,200 @@
+package otelx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/caioreix/errorsx/otelx"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var errOrderNotFound = errorsx.DefineCode(errorsx.Code{
+	Code:       "OTELX_ORDER_NOT_FOUND",
+	Message:    "order not found",
+	HTTPStatus: http.StatusNotFound,
+})
+
+// record records err on a new span and returns the span once ended.
+func record(t *testing.T, err error, opts ...otelx.Option) sdktrace.ReadOnlySpan {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(t.Context()) })
+
+	_, span := tp.Tracer("otelx_test").Start(t.Context(), "op")
+	otelx.RecordError(span, err, opts...)
+	span.End()
+
+	spans := exporter.GetSpans().Snapshots()
+	require.Len(t, spans, 1)
+	return spans[0]
+}
+
+func eventAttrs(t *testing.T, span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	t.Helper()
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, otelx.ExceptionEventName, span.Events()[0].Name)
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Events()[0].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+
+	return attrs
+}
+
+func TestRecordError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ErrorX", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewWithError(errors.New("bar"), "foo").With("user_id", 42)
+
+		span := record(t, errX)
+
+		attrs := eventAttrs(t, span)
+		assert.Equal(t, fmt.Sprintf("%T", errX), attrs[otelx.ExceptionType].AsString())
+		assert.Equal(t, "foo: bar", attrs[otelx.ExceptionMessage].AsString())
+		assert.Equal(t, "foo", attrs["message"].AsString())
+		assert.Equal(t, "bar", attrs["error"].AsString())
+		assert.Equal(t, errX.Caller(), attrs["caller"].AsString())
+		assert.EqualValues(t, 42, attrs["user_id"].AsInt64())
+		assert.NotContains(t, attrs, attribute.Key("stack"))
+
+		stack := errX.Stack()
+		require.NotEmpty(t, stack)
+		assert.Contains(t, attrs[otelx.ExceptionStacktrace].AsString(),
+			stack[0].Function+"\n\t"+stack[0].File+":"+strconv.Itoa(stack[0].Line)+"\n")
+
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Equal(t, errX.Error(), span.Status().Description)
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		t.Parallel()
+		span := record(t, fmt.Errorf("op: %w", errorsx.NewHTTPWithError(errors.New("bar"), http.StatusBadGateway, "foo")))
+
+		attrs := eventAttrs(t, span)
+		assert.Equal(t, "op: foo: bar: status 502", attrs[otelx.ExceptionMessage].AsString())
+	})
+
+	t.Run("code", func(t *testing.T) {
+		t.Parallel()
+		span := record(t, fmt.Errorf("handler: %w", errOrderNotFound.New()))
+
+		attrs := eventAttrs(t, span)
+		assert.Equal(t, "OTELX_ORDER_NOT_FOUND", attrs[otelx.ExceptionType].AsString())
+		assert.Equal(t, "OTELX_ORDER_NOT_FOUND", attrs["code"].AsString())
+		assert.EqualValues(t, http.StatusNotFound, attrs["status"].AsInt64())
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		t.Parallel()
+
+		type User struct {
+			Name string `validate:"required"`
+		}
+		validationErr := validator.New().Struct(User{})
+
+		span := record(t, errorsx.NewHTTPWithError(validationErr, http.StatusBadRequest, "foo"))
+
+		attrs := eventAttrs(t, span)
+		assert.JSONEq(t, fmt.Sprintf(`[{
+			"tag": "required",
+			"param": "",
+			"value": "",
+			"namespace": "User.Name",
+			"struct_namespace": "User.Name",
+			"message": %q
+		}]`, validationErr.(validator.ValidationErrors)[0].Error()), attrs["validation_errors.User.Name"].AsString())
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		t.Parallel()
+		span := record(t, errors.New("foo"))
+
+		attrs := eventAttrs(t, span)
+		assert.Equal(t, "foo", attrs[otelx.ExceptionMessage].AsString())
+		assert.Equal(t, codes.Error, span.Status().Code)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		span := record(t, nil)
+
+		assert.Empty(t, span.Events())
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	})
+}
+
+func TestRecordError_Status(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		status int
+		opts   []otelx.Option
+		want   codes.Code
+	}{
+		{status: http.StatusInternalServerError, want: codes.Error},
+		{status: http.StatusServiceUnavailable, want: codes.Error},
+		{status: http.StatusNotFound, want: codes.Unset},
+		{status: http.StatusNotFound, opts: []otelx.Option{otelx.WithClientErrors()}, want: codes.Error},
+		{status: http.StatusFound, opts: []otelx.Option{otelx.WithClientErrors()}, want: codes.Unset},
+	}
+
+	for _, tc := range tt {
+		t.Run(strconv.Itoa(tc.status), func(t *testing.T) {
+			t.Parallel()
+			span := record(t, errorsx.NewHTTP(tc.status, "foo"), tc.opts...)
+
+			assert.Len(t, span.Events(), 1)
+			assert.Equal(t, tc.want, span.Status().Code)
+		})
+	}
+}
+
+func spanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	return trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID})
+}
+
+func TestTraceContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, otelx.TraceContext(t.Context()))
+
+	ctx := trace.ContextWithSpanContext(t.Context(), spanContext(t))
+	assert.Equal(t, map[string]any{
+		"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":  "00f067aa0ba902b7",
+	}, otelx.TraceContext(ctx))
+}
+
+func TestRegisterTraceExtractor(t *testing.T) {
+	defer errorsx.UnregisterContextExtractor(otelx.TraceExtractorName)
+	ctx := trace.ContextWithSpanContext(t.Context(), spanContext(t))
+
+	assert.Empty(t, errorsx.Attributes(errorsx.NewCtx(ctx, "foo")))
+
+	otelx.RegisterTraceExtractor()
+	assert.Equal(t, map[string]any{
+		"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id":  "00f067aa0ba902b7",
+	}, errorsx.Attributes(errorsx.NewCtx(ctx, "foo")))
+}
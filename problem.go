@@ -0,0 +1,236 @@
+package errorsx
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ProblemContentType is the media type of RFC 9457 Problem Details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 Problem Details object. Extensions holds the
+// extension members, encoded next to the standard ones.
+type Problem struct {
+	Type       string         `json:"type,omitempty"`
+	Title      string         `json:"title,omitempty"`
+	Status     int            `json:"status,omitempty"`
+	Detail     string         `json:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty"`
+	Errors     []ProblemError `json:"errors,omitempty"`
+	Extensions map[string]any `json:"-"`
+}
+
+// ProblemError is a single validation failure in the "errors" member of a
+// Problem.
+type ProblemError struct {
+	// Field is the path of the field, as FieldPath returns it.
+	Field  string `json:"field"`
+	Tag    string `json:"tag"`
+	Param  string `json:"param,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ProblemPolicy controls which parts of an error end up in a Problem.
+type ProblemPolicy struct {
+	// TypeBaseURI, when set, makes the type member of errors carrying a Code
+	// TypeBaseURI followed by the code. Other problems are "about:blank".
+	TypeBaseURI string
+	// Extensions lists the Fields() keys exposed as extension members.
+	// Keys clashing with a standard member are ignored.
+	Extensions []string
+	// OmitDetail leaves the detail member out instead of filling it with the
+	// public message of the error.
+	OmitDetail bool
+	// Debug exposes the internal fields of the error as extension members,
+	// as does the package-wide debug mode. Otherwise they are never exposed,
+	// even when listed in Extensions.
+	Debug bool
+}
+
+// DefaultProblemPolicy is used when no ProblemPolicy is given. It exposes
+// the error code and message only, leaving causes, callers and stacks out.
+var DefaultProblemPolicy = ProblemPolicy{
+	Extensions: []string{"code"},
+}
+
+var problemMembers = []string{"type", "title", "status", "detail", "instance", "errors"}
+
+// NewProblem renders err as a Problem following policy, or the
+// DefaultProblemPolicy when policy is nil. The status is the one of the
+// outermost HTTPStatuser in the chain, defaulting to 500, and the detail is
+// the PublicMessage of err.
+func NewProblem(err error, policy *ProblemPolicy) *Problem {
+	if policy == nil {
+		policy = &DefaultProblemPolicy
+	}
+
+	status, ok := HTTPStatus(err)
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	if code, ok := ErrorCode(err); ok && policy.TypeBaseURI != "" {
+		p.Type = policy.TypeBaseURI + code.Code
+	}
+
+	var ex ErrorX
+	if errors.As(err, &ex) {
+		if !policy.OmitDetail {
+			p.Detail = PublicMessage(err)
+		}
+
+		debug := policy.Debug || GetDebug()
+		extensions := policy.Extensions
+		if debug {
+			extensions = append(slices.Clip(extensions), internalFields...)
+		}
+
+		fields := ex.Fields()
+		for _, k := range extensions {
+			if !debug && slices.Contains(internalFields, k) {
+				continue
+			}
+
+			if v, ok := fields[k]; ok && !slices.Contains(problemMembers, k) {
+				if p.Extensions == nil {
+					p.Extensions = make(map[string]any)
+				}
+				p.Extensions[k] = v
+			}
+		}
+	}
+
+	if fieldErrs, ok := FieldErrors(err); ok {
+		msgs := FieldMessages(err)
+		for i, fe := range fieldErrs {
+			p.Errors = append(p.Errors, ProblemError{
+				Field:  FieldPath(fe),
+				Tag:    fe.Tag(),
+				Param:  fe.Param(),
+				Detail: msgs[i],
+			})
+		}
+	}
+
+	return p
+}
+
+// WriteProblem writes err to w as an application/problem+json response
+// rendered by NewProblem, with the request path as the instance member.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error, policy *ProblemPolicy) error {
+	p := NewProblem(err, policy)
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.Path
+	}
+
+	data, jerr := json.Marshal(p)
+	if jerr != nil {
+		return jerr
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_, werr := w.Write(data)
+	return werr
+}
+
+// ParseProblem reads a Problem from r and turns it back into an ErrorX
+// carrying its status, with the detail, or else the title, as message. The
+// "errors" member becomes validation errors and a "code" extension member
+// naming a defined Code restores it.
+func ParseProblem(r io.Reader) (ErrorX, error) {
+	var p Problem
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, err
+	}
+
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+
+	var e ErrorX = newf(nil, nil, "%s", msg)
+	if len(p.Errors) != 0 {
+		ve := &validationErrorX{ErrorX: e, validation: options{}.validation()}
+		for _, pe := range p.Errors {
+			v := FieldViolation{Path: pe.Field, Rule: pe.Tag, Message: pe.Detail, Params: strings.Fields(pe.Param)}
+			ve.fieldErrors = append(ve.fieldErrors, &violation{v: v, path: splitNamespace(v.Path)})
+		}
+		e = ve
+	}
+
+	if p.Status != 0 {
+		e = &httpErrorX{ErrorX: e, status: p.Status}
+	}
+
+	if code, ok := p.Extensions["code"].(string); ok {
+		if c, ok := LookupCode(code); ok {
+			e = &codeErrorX{ErrorX: e, code: c}
+		}
+	}
+
+	return e, nil
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+
+	data, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]json.RawMessage, len(p.Extensions)+len(problemMembers))
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(p.Extensions)) {
+		if _, ok := members[k]; ok || slices.Contains(problemMembers, k) {
+			continue
+		}
+
+		v, err := json.Marshal(p.Extensions[k])
+		if err != nil {
+			return nil, err
+		}
+		members[k] = v
+	}
+
+	return json.Marshal(members)
+}
+
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	type problem Problem
+
+	var pp problem
+	if err := json.Unmarshal(data, &pp); err != nil {
+		return err
+	}
+
+	var members map[string]any
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	for _, k := range problemMembers {
+		delete(members, k)
+	}
+	if len(members) != 0 {
+		pp.Extensions = members
+	}
+
+	*p = Problem(pp)
+	return nil
+}
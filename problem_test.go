@@ -0,0 +1,266 @@
+package errorsx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProblem(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	validationErr := validator.New().Struct(User{})
+
+	tt := []struct {
+		name   string
+		err    error
+		policy *errorsx.ProblemPolicy
+		want   *errorsx.Problem
+	}{
+		{
+			name: "plain error",
+			err:  errors.New("foo"),
+			want: &errorsx.Problem{
+				Type:   "about:blank",
+				Title:  http.StatusText(http.StatusInternalServerError),
+				Status: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "HTTPErrorX",
+			err:  errorsx.NewHTTPWithError(errors.New("secret"), http.StatusConflict, "foo"),
+			want: &errorsx.Problem{
+				Type:   "about:blank",
+				Title:  http.StatusText(http.StatusConflict),
+				Status: http.StatusConflict,
+				Detail: "foo",
+			},
+		},
+		{
+			name: "validation errors",
+			err:  errorsx.NewHTTPWithError(validationErr, http.StatusBadRequest, "foo"),
+			want: &errorsx.Problem{
+				Type:   "about:blank",
+				Title:  http.StatusText(http.StatusBadRequest),
+				Status: http.StatusBadRequest,
+				Detail: "foo",
+				Errors: []errorsx.ProblemError{
+					{
+						Field:  "Name",
+						Tag:    "required",
+						Detail: validationErr.(validator.ValidationErrors)[0].Error(),
+					},
+				},
+			},
+		},
+		{
+			name: "code with default policy",
+			err:  errUserNotFound.New(),
+			want: &errorsx.Problem{
+				Type:       "about:blank",
+				Title:      http.StatusText(http.StatusNotFound),
+				Status:     http.StatusNotFound,
+				Detail:     "user not found",
+				Extensions: map[string]any{"code": "USER_NOT_FOUND"},
+			},
+		},
+		{
+			name: "custom policy",
+			err:  errUserNotFound.New(),
+			policy: &errorsx.ProblemPolicy{
+				TypeBaseURI: "https://example.com/problems/",
+				Extensions:  []string{"status", "code", "missing"},
+				OmitDetail:  true,
+			},
+			want: &errorsx.Problem{
+				Type:       "https://example.com/problems/USER_NOT_FOUND",
+				Title:      http.StatusText(http.StatusNotFound),
+				Status:     http.StatusNotFound,
+				Extensions: map[string]any{"code": "USER_NOT_FOUND"},
+			},
+		},
+		{
+			name: "internal fields",
+			err:  errorsx.NewHTTPWithError(errors.New("secret"), http.StatusInternalServerError, "foo"),
+			policy: &errorsx.ProblemPolicy{
+				Extensions: []string{"error", "caller", "stack", "status"},
+			},
+			want: &errorsx.Problem{
+				Type:   "about:blank",
+				Title:  http.StatusText(http.StatusInternalServerError),
+				Status: http.StatusInternalServerError,
+				Detail: "foo",
+			},
+		},
+		{
+			name: "public message",
+			err: errorsx.NewHTTPWithError(errors.New("secret"), http.StatusConflict, "update users",
+				errorsx.WithPublicMessage("user changed")),
+			want: &errorsx.Problem{
+				Type:   "about:blank",
+				Title:  http.StatusText(http.StatusConflict),
+				Status: http.StatusConflict,
+				Detail: "user changed",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := errorsx.NewProblem(tc.err, tc.policy)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("debug", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewHTTPWithError(errors.New("secret"), http.StatusInternalServerError, "foo")
+
+		got := errorsx.NewProblem(errX, &errorsx.ProblemPolicy{Debug: true})
+		assert.Equal(t, map[string]any{
+			"error":  "secret",
+			"caller": errX.Caller(),
+			"stack":  errX.Stack(),
+		}, got.Extensions)
+	})
+}
+
+func TestNewProblem_Errors(t *testing.T) {
+	t.Parallel()
+
+	type Item struct {
+		Name string `validate:"required"`
+	}
+	type Order struct {
+		Items []Item `validate:"dive"`
+	}
+
+	validate := validator.New()
+	trans, _ := ut.New(en.New()).GetTranslator("en")
+	require.NoError(t, en_translations.RegisterDefaultTranslations(validate, trans))
+	validationErr := validate.Struct(Order{Items: make([]Item, 2)})
+
+	errX := errorsx.NewHTTPWithError(validationErr, http.StatusBadRequest, "foo", errorsx.WithTranslator(trans))
+	got := errorsx.NewProblem(errX, nil)
+
+	assert.Equal(t, []errorsx.ProblemError{
+		{Field: "Items[0].Name", Tag: "required", Detail: "Name is a required field"},
+		{Field: "Items[1].Name", Tag: "required", Detail: "Name is a required field"},
+	}, got.Errors)
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		data, err := json.Marshal(got)
+		require.NoError(t, err)
+
+		parsed, err := errorsx.ParseProblem(strings.NewReader(string(data)))
+		require.NoError(t, err)
+		assert.Equal(t, got.Errors, errorsx.NewProblem(parsed, nil).Errors)
+	})
+}
+
+func TestNewProblem_DebugMode(t *testing.T) {
+	defer errorsx.SetDebug(errorsx.GetDebug())
+	errorsx.SetDebug(true)
+
+	errX := errorsx.NewWithError(errors.New("secret"), "foo")
+	got := errorsx.NewProblem(errX, nil)
+	assert.Equal(t, "secret", got.Extensions["error"])
+	assert.Equal(t, errX.Caller(), got.Extensions["caller"])
+	assert.Contains(t, got.Extensions, "stack")
+}
+
+func TestWriteProblem(t *testing.T) {
+	t.Parallel()
+	err := errUserNotFound.NewWithError(errors.New("sql: no rows"))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/42?expand=true", nil)
+	require.NoError(t, errorsx.WriteProblem(rec, req, err, nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, errorsx.ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, map[string]any{
+		"type":     "about:blank",
+		"title":    http.StatusText(http.StatusNotFound),
+		"status":   float64(http.StatusNotFound),
+		"detail":   "user not found",
+		"instance": "/users/42",
+		"code":     "USER_NOT_FOUND",
+	}, got)
+}
+
+func TestParseProblem(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with code and errors", func(t *testing.T) {
+		t.Parallel()
+		body := `{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "user not found",
+			"code": "USER_NOT_FOUND",
+			"errors": [{"field": "ID", "tag": "uuid", "detail": "ID must be a UUID"}]
+		}`
+
+		got, err := errorsx.ParseProblem(strings.NewReader(body))
+		require.NoError(t, err)
+
+		assert.Regexp(t, callerRX("user not found: code USER_NOT_FOUND: status 404"), got.Error())
+		assert.ErrorIs(t, got, errUserNotFound)
+
+		status, ok := errorsx.HTTPStatus(got)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, status)
+
+		fieldErrs, ok := errorsx.FieldErrors(got)
+		require.True(t, ok)
+		require.Len(t, fieldErrs, 1)
+		assert.Equal(t, "ID", fieldErrs[0].Field())
+		assert.Equal(t, "uuid", fieldErrs[0].Tag())
+		assert.Equal(t, "ID must be a UUID", fieldErrs[0].Error())
+	})
+
+	t.Run("title only", func(t *testing.T) {
+		t.Parallel()
+		got, err := errorsx.ParseProblem(strings.NewReader(`{"title":"Conflict","status":409}`))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"message": "Conflict", "status": 409}, got.Fields("message", "status"))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		require.NoError(t, errorsx.WriteProblem(rec, nil, errorsx.NewHTTP(http.StatusConflict, "foo"), nil))
+
+		got, err := errorsx.ParseProblem(rec.Body)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"message": "foo", "status": http.StatusConflict}, got.Fields("message", "status"))
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		t.Parallel()
+		_, err := errorsx.ParseProblem(strings.NewReader(`{`))
+		assert.Error(t, err)
+	})
+}
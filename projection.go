@@ -0,0 +1,130 @@
+package errorsx
+
+import (
+	"slices"
+	"strings"
+)
+
+// Projection selects fields of an error by dotted paths into the nested
+// maps of Fields(), such as "validation_errors.User.Name". Keys containing
+// dots are matched as a whole before being split, longest first.
+type Projection struct {
+	// Include lists the paths to keep. Empty keeps every field.
+	Include []string
+	// Exclude lists the paths to remove from what Include kept.
+	Exclude []string
+}
+
+// Project returns the fields of e the way Fields() does, projected by p.
+// Fields(paths...) is Project with paths as Include.
+func Project(e ErrorX, p Projection) map[string]any {
+	return project(e, p)
+}
+
+// wants reports whether the top-level key may hold something p keeps.
+func (p Projection) wants(key string) bool {
+	if slices.Contains(p.Exclude, key) {
+		return false
+	}
+	if len(p.Include) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(p.Include, func(path string) bool {
+		return path == key || strings.HasPrefix(path, key+".")
+	})
+}
+
+// apply projects fields, which it may modify, by the paths of p.
+func (p Projection) apply(fields map[string]any) map[string]any {
+	if len(p.Include) != 0 {
+		selected := make(map[string]any)
+		for _, path := range p.Include {
+			selectPath(selected, fields, path)
+		}
+		fields = selected
+	}
+
+	for _, path := range p.Exclude {
+		removePath(fields, path)
+	}
+
+	return fields
+}
+
+// selectPath copies the value found at path in src into dst, creating the
+// nested maps leading to it.
+func selectPath(dst, src map[string]any, path string) {
+	if v, ok := src[path]; ok {
+		dst[path] = v
+		return
+	}
+
+	for i := strings.LastIndexByte(path, '.'); i > 0; i = strings.LastIndexByte(path[:i], '.') {
+		nested, ok := src[path[:i]].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		sub, ok := dst[path[:i]].(map[string]any)
+		if !ok {
+			sub = make(map[string]any)
+			dst[path[:i]] = sub
+		}
+		selectPath(sub, nested, path[i+1:])
+		if len(sub) == 0 {
+			delete(dst, path[:i])
+		}
+		return
+	}
+}
+
+// removePath deletes the value found at path in m, along with the nested
+// maps it leaves empty.
+func removePath(m map[string]any, path string) {
+	if _, ok := m[path]; ok {
+		delete(m, path)
+		return
+	}
+
+	for i := strings.LastIndexByte(path, '.'); i > 0; i = strings.LastIndexByte(path[:i], '.') {
+		nested, ok := m[path[:i]].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		removePath(nested, path[i+1:])
+		if len(nested) == 0 {
+			delete(m, path[:i])
+		}
+		return
+	}
+}
+
+// LayerView is what one layer of an error contributes to Fields().
+type LayerView struct {
+	Layer Layer
+	// Fields are the fields of the layer, with sensitive values hidden
+	// following the RedactionPolicy of the error. The ones of the innermost
+	// layer include its caller and stack.
+	Fields map[string]any
+}
+
+// Layers returns the layers of e, outermost first, with the fields each one
+// contributes, where Fields() keeps only the outermost value of a key set by
+// several layers.
+func Layers(e ErrorX) []LayerView {
+	p := redactionOf(e)
+
+	var views []LayerView
+	for ex := e; ex != nil; ex = ex.Inner() {
+		fields := p.redactFields(ex.LayerFields())
+		if ex.Inner() == nil {
+			fields["caller"] = ex.Caller()
+			fields["stack"] = ex.Stack().Filter(GetStackFilter())
+		}
+		views = append(views, LayerView{Layer: ex, Fields: fields})
+	}
+
+	return views
+}
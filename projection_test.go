@@ -0,0 +1,149 @@
+package errorsx_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorX_Fields_CallerAndStack(t *testing.T) {
+	t.Parallel()
+	errX := errorsx.New("foo")
+
+	assert.Equal(t, map[string]any{"caller": errX.Caller()}, errX.Fields("caller"))
+	assert.Equal(t, map[string]any{"stack": errX.Stack().Filter(errorsx.GetStackFilter())}, errX.Fields("stack"))
+	assert.Equal(t, map[string]any{"message": "foo"}, errX.Fields("message"))
+}
+
+func TestProject(t *testing.T) {
+	t.Parallel()
+
+	type Item struct {
+		Name string `validate:"required"`
+	}
+	type User struct {
+		Email string `validate:"required"`
+		Items []Item `validate:"dive"`
+	}
+
+	validationErr := validator.New().Struct(User{Items: []Item{{}}})
+	fieldErrs := validationErr.(validator.ValidationErrors)
+
+	flat := errorsx.NewHTTPWithError(validationErr, http.StatusBadRequest, "foo").
+		With("http.method", "GET", "user_id", 42)
+	nested := errorsx.NewWithError(validationErr, "foo",
+		errorsx.WithValidationPolicy(errorsx.ValidationPolicy{Mode: errorsx.ValidationNested}))
+
+	tt := []struct {
+		name string
+		errX errorsx.ErrorX
+		p    errorsx.Projection
+		want map[string]any
+	}{
+		{
+			name: "keys",
+			errX: flat,
+			p:    errorsx.Projection{Include: []string{"message", "status"}},
+			want: map[string]any{"message": "foo", "status": http.StatusBadRequest},
+		},
+		{
+			name: "dotted key",
+			errX: flat,
+			p:    errorsx.Projection{Include: []string{"http.method"}},
+			want: map[string]any{"http.method": "GET"},
+		},
+		{
+			name: "path into a flat namespace",
+			errX: flat,
+			p:    errorsx.Projection{Include: []string{"validation_errors.User.Email", "missing.path"}},
+			want: map[string]any{"validation_errors": map[string]any{
+				"User.Email": []map[string]any{wantFieldEntry(fieldErrs[0], "")},
+			}},
+		},
+		{
+			name: "path into nested maps",
+			errX: nested,
+			p:    errorsx.Projection{Include: []string{"validation_errors.Items.0.Name"}},
+			want: map[string]any{"validation_errors": map[string]any{
+				"Items": map[string]any{"0": map[string]any{
+					"Name": []map[string]any{wantFieldEntry(fieldErrs[1], "")},
+				}},
+			}},
+		},
+		{
+			name: "exclude",
+			errX: flat,
+			p: errorsx.Projection{
+				Include: []string{"message", "user_id", "validation_errors"},
+				Exclude: []string{"user_id", "validation_errors.User.Items[0].Name"},
+			},
+			want: map[string]any{"message": "foo", "validation_errors": map[string]any{
+				"User.Email": []map[string]any{wantFieldEntry(fieldErrs[0], "")},
+			}},
+		},
+		{
+			name: "exclude emptying a map",
+			errX: nested,
+			p: errorsx.Projection{
+				Include: []string{"message", "validation_errors.Items"},
+				Exclude: []string{"validation_errors.Items.0.Name"},
+			},
+			want: map[string]any{"message": "foo"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, errorsx.Project(tc.errX, tc.p))
+		})
+	}
+
+	t.Run("exclude only", func(t *testing.T) {
+		t.Parallel()
+		got := errorsx.Project(flat, errorsx.Projection{Exclude: []string{"stack", "caller", "validation_errors"}})
+		assert.Equal(t, map[string]any{
+			"message":     "foo",
+			"error":       validationErr.Error(),
+			"status":      http.StatusBadRequest,
+			"http.method": "GET",
+			"user_id":     42,
+		}, got)
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, errorsx.Project(flat, errorsx.Projection{Include: []string{"validation_errors.User.Email"}}),
+			flat.Fields("validation_errors.User.Email"))
+	})
+}
+
+func TestLayers(t *testing.T) {
+	t.Parallel()
+	inner := errorsx.NewHTTPWithError(errors.New("bar"), http.StatusNotFound, "foo").With("user_id", 1)
+	errX := inner.With("user_id", 2, "token", errorsx.Sensitive("s3cr3t"))
+
+	views := errorsx.Layers(errX)
+	require.Len(t, views, 4)
+
+	assert.Equal(t, errX, views[0].Layer)
+	assert.Equal(t, map[string]any{"user_id": 2, "token": errorsx.RedactedValue}, views[0].Fields)
+	assert.Equal(t, map[string]any{"user_id": 1}, views[1].Fields)
+	assert.Equal(t, map[string]any{"status": http.StatusNotFound}, views[2].Fields)
+
+	innermost := views[3].Layer.(errorsx.ErrorX)
+	assert.Nil(t, innermost.Inner())
+	assert.Equal(t, map[string]any{
+		"message": "foo",
+		"error":   "bar",
+		"caller":  errX.Caller(),
+		"stack":   errX.Stack().Filter(errorsx.GetStackFilter()),
+	}, views[3].Fields)
+
+	assert.Equal(t, 2, errX.Fields("user_id")["user_id"])
+}
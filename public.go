@@ -0,0 +1,68 @@
+package errorsx
+
+import (
+	"net/http"
+	"slices"
+	"sync/atomic"
+)
+
+// PublicMessager is implemented by errors carrying a message that is safe to
+// show to clients, unlike their causes, callers and stacks.
+type PublicMessager interface {
+	PublicMessage() string
+}
+
+// WithPublicMessage gives the error msg as public message instead of its
+// message, which is then kept for internal use such as logs.
+func WithPublicMessage(msg string) Option {
+	return func(o *options) {
+		o.publicMessage = msg
+	}
+}
+
+// PublicMessage returns the message of err that is safe to show to clients:
+// the one of the outermost PublicMessager found in the chain of err,
+// including errors.Join branches. An ErrorX's public message is the one given
+// by WithPublicMessage, or else its message without its cause. It falls back
+// to the text of the status of err, or of 500.
+func PublicMessage(err error) string {
+	var msg string
+	walk(err, func(e error) bool {
+		if pm, ok := e.(PublicMessager); ok {
+			msg = pm.PublicMessage()
+		}
+		return msg == ""
+	})
+
+	if msg != "" {
+		return msg
+	}
+
+	status, ok := HTTPStatus(err)
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	return http.StatusText(status)
+}
+
+var debug atomic.Bool
+
+// SetDebug turns the package-wide debug mode on or off. Renderers such as
+// WriteProblem only expose internal fields in debug mode.
+func SetDebug(on bool) {
+	debug.Store(on)
+}
+
+// GetDebug reports whether the package-wide debug mode is on.
+func GetDebug() bool {
+	return debug.Load()
+}
+
+var internalFields = []string{"error", "caller", "stack"}
+
+// InternalFields returns the Fields() keys holding internal detail, which
+// renderers leave out unless in debug mode: the cause, caller and stack.
+func InternalFields() []string {
+	return slices.Clone(internalFields)
+}
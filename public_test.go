@@ -0,0 +1,98 @@
+package errorsx_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicMessage(t *testing.T) {
+	t.Parallel()
+	dbErr := errors.New("pq: relation \"users\" does not exist")
+
+	tt := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "nil",
+			want: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name: "plain error",
+			err:  dbErr,
+			want: http.StatusText(http.StatusInternalServerError),
+		},
+		{
+			name: "ErrorX message without cause",
+			err:  errorsx.NewHTTPWithError(dbErr, http.StatusInternalServerError, "failed"),
+			want: "failed",
+		},
+		{
+			name: "WithPublicMessage",
+			err:  errorsx.NewWithError(dbErr, "select users", errorsx.WithPublicMessage("could not list users")),
+			want: "could not list users",
+		},
+		{
+			name: "outermost ErrorX",
+			err:  fmt.Errorf("handler: %w", errorsx.NewWithError(errorsx.New("inner"), "outer")),
+			want: "outer",
+		},
+		{
+			name: "empty message",
+			err:  errorsx.NewHTTPWithError(errorsx.New("inner"), http.StatusNotFound, ""),
+			want: "inner",
+		},
+		{
+			name: "status text fallback",
+			err:  errorsx.NewHTTP(http.StatusConflict, ""),
+			want: http.StatusText(http.StatusConflict),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, errorsx.PublicMessage(tc.err))
+		})
+	}
+
+	t.Run("survives encoding", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.New("select users", errorsx.WithPublicMessage("could not list users"))
+
+		data, err := errorsx.EncodeJSON(errX)
+		require.NoError(t, err)
+		got, err := errorsx.DecodeJSON(data)
+		require.NoError(t, err)
+
+		assert.Equal(t, "could not list users", errorsx.PublicMessage(got))
+		assert.Equal(t, errX.Error(), got.Error())
+	})
+}
+
+func TestSetDebug(t *testing.T) {
+	defer errorsx.SetDebug(errorsx.GetDebug())
+
+	errorsx.SetDebug(true)
+	assert.True(t, errorsx.GetDebug())
+
+	errorsx.SetDebug(false)
+	assert.False(t, errorsx.GetDebug())
+}
+
+func TestInternalFields(t *testing.T) {
+	t.Parallel()
+
+	got := errorsx.InternalFields()
+	assert.Equal(t, []string{"error", "caller", "stack"}, got)
+
+	got[0] = "foo"
+	assert.Equal(t, "error", errorsx.InternalFields()[0])
+}
@@ -0,0 +1,220 @@
+package errorsx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RedactMode selects what replaces sensitive values.
+type RedactMode int
+
+const (
+	// RedactMask replaces sensitive values with the Mask of the policy.
+	RedactMask RedactMode = iota
+	// RedactHash replaces sensitive values with "sha256:" followed by the
+	// first 16 hex digits of their hash, so equal values can still be
+	// correlated across errors.
+	RedactHash
+)
+
+// RedactionPolicy controls which values Fields(), the JSON encoding and
+// LogValue hide, and how. Values wrapped by Sensitive are always hidden.
+type RedactionPolicy struct {
+	Mode RedactMode
+	// Mask replaces sensitive values in RedactMask mode. Empty means
+	// RedactedValue.
+	Mask string
+	// HashKey makes RedactHash use HMAC-SHA256 keyed by it, which keeps
+	// short values such as IDs from being recovered by brute force.
+	HashKey []byte
+	// Keys lists case-insensitive path.Match patterns, such as "*password*"
+	// or "authorization", for the field keys whose values are sensitive at
+	// any depth. Validation failures match them against their field name and
+	// namespace to hide their offending values.
+	Keys []string
+}
+
+var redactionPolicy atomic.Pointer[RedactionPolicy]
+
+// SetRedactionPolicy sets the package-wide RedactionPolicy used by every
+// error not given WithRedactionPolicy, including the ones already created.
+func SetRedactionPolicy(p RedactionPolicy) {
+	redactionPolicy.Store(&p)
+}
+
+// GetRedactionPolicy returns the package-wide RedactionPolicy.
+func GetRedactionPolicy() RedactionPolicy {
+	if p := redactionPolicy.Load(); p != nil {
+		return *p
+	}
+
+	return RedactionPolicy{}
+}
+
+// WithRedactionPolicy makes the error hide its sensitive values following p
+// instead of the package-wide RedactionPolicy.
+func WithRedactionPolicy(p RedactionPolicy) Option {
+	return func(o *options) {
+		o.redactionPolicy = &p
+	}
+}
+
+// SensitiveValue holds a value that must not show up in error output. It
+// renders as RedactedValue through fmt, slog and encoding/json, and is
+// replaced following the RedactionPolicy of the error in Fields().
+type SensitiveValue struct {
+	value any
+}
+
+// Sensitive marks v as sensitive, to be attached to an error in place of v.
+func Sensitive(v any) SensitiveValue {
+	return SensitiveValue{value: v}
+}
+
+// Value returns the value s hides.
+func (s SensitiveValue) Value() any {
+	return s.value
+}
+
+func (s SensitiveValue) String() string {
+	return RedactedValue
+}
+
+func (s SensitiveValue) LogValue() slog.Value {
+	return slog.StringValue(RedactedValue)
+}
+
+func (s SensitiveValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedValue)
+}
+
+// SensitiveKey reports whether key matches one of the Keys of p.
+func (p RedactionPolicy) SensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range p.Keys {
+		if ok, _ := path.Match(strings.ToLower(pattern), key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Redact returns the replacement of the sensitive value v.
+func (p RedactionPolicy) Redact(v any) string {
+	if s, ok := v.(SensitiveValue); ok {
+		v = s.value
+	}
+
+	if p.Mode != RedactHash {
+		if p.Mask != "" {
+			return p.Mask
+		}
+		return RedactedValue
+	}
+
+	var sum []byte
+	if len(p.HashKey) != 0 {
+		mac := hmac.New(sha256.New, p.HashKey)
+		_, _ = fmt.Fprint(mac, v)
+		sum = mac.Sum(nil)
+	} else {
+		h := sha256.Sum256([]byte(fmt.Sprint(v)))
+		sum = h[:]
+	}
+
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// redactFields returns a copy of fields with the values of sensitive keys
+// and every SensitiveValue replaced. Maps and lists of maps under a
+// sensitive key have every value they nest replaced. Validation failures
+// only have their SensitiveValues replaced: they are keyed by field
+// namespaces and already hide the values of sensitive fields.
+func (p RedactionPolicy) redactFields(fields map[string]any) map[string]any {
+	redacted := make(map[string]any, len(fields))
+	for k, v := range fields {
+		if k == validationErrorsKey {
+			redacted[k] = p.redactSensitive(v)
+			continue
+		}
+		redacted[k] = p.redactValue(p.SensitiveKey(k), v)
+	}
+
+	return redacted
+}
+
+// redactValue returns v with its sensitive values replaced: all of them
+// when sensitive is set.
+func (p RedactionPolicy) redactValue(sensitive bool, v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, nested := range v {
+			redacted[k] = p.redactValue(sensitive || p.SensitiveKey(k), nested)
+		}
+		return redacted
+	case []map[string]any:
+		list := make([]map[string]any, len(v))
+		for i, m := range v {
+			list[i], _ = p.redactValue(sensitive, m).(map[string]any)
+		}
+		return list
+	case SensitiveValue:
+		return p.Redact(v)
+	}
+
+	if sensitive {
+		return p.Redact(v)
+	}
+
+	return v
+}
+
+// redactSensitive returns v with only the SensitiveValues it nests replaced.
+func (p RedactionPolicy) redactSensitive(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, nested := range v {
+			redacted[k] = p.redactSensitive(nested)
+		}
+		return redacted
+	case []map[string]any:
+		list := make([]map[string]any, len(v))
+		for i, m := range v {
+			list[i], _ = p.redactSensitive(m).(map[string]any)
+		}
+		return list
+	case SensitiveValue:
+		return p.Redact(v)
+	}
+
+	return v
+}
+
+// sensitiveField reports whether the offending value of fe is sensitive.
+func (p RedactionPolicy) sensitiveField(fe validator.FieldError) bool {
+	return p.SensitiveKey(fe.Field()) || p.SensitiveKey(fe.Namespace())
+}
+
+// redactionOf returns the RedactionPolicy of e, which its innermost layer
+// captured when it was created.
+func redactionOf(e ErrorX) RedactionPolicy {
+	for ex := e; ex != nil; ex = ex.Inner() {
+		if inner, ok := ex.(*errorX); ok && inner.redaction != nil {
+			return *inner.redaction
+		}
+	}
+
+	return GetRedactionPolicy()
+}
@@ -0,0 +1,223 @@
+package errorsx_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// credentialErrorX is a custom layer carrying a sensitive token.
+type credentialErrorX struct {
+	errorsx.ErrorX
+
+	user  string
+	token errorsx.SensitiveValue
+}
+
+func (e *credentialErrorX) Fields(fields ...string) map[string]any {
+	return errorsx.Mapify(e, fields...)
+}
+
+func (e *credentialErrorX) LayerMessage() string {
+	return "credential"
+}
+
+func (e *credentialErrorX) LayerFields() map[string]any {
+	return map[string]any{"user": e.user, "token": e.token}
+}
+
+func (e credentialErrorX) Inner() errorsx.ErrorX {
+	return e.ErrorX
+}
+
+func TestRedactionPolicy_SensitiveKey(t *testing.T) {
+	t.Parallel()
+	p := errorsx.RedactionPolicy{Keys: []string{"*password*", "Authorization"}}
+
+	tt := []struct {
+		key  string
+		want bool
+	}{
+		{key: "password", want: true},
+		{key: "user_password_hash", want: true},
+		{key: "User.Password", want: true},
+		{key: "authorization", want: true},
+		{key: "AUTHORIZATION", want: true},
+		{key: "proxy_authorization", want: false},
+		{key: "user", want: false},
+	}
+
+	for _, tc := range tt {
+		assert.Equal(t, tc.want, p.SensitiveKey(tc.key), tc.key)
+	}
+}
+
+func TestRedactionPolicy_Redact(t *testing.T) {
+	t.Parallel()
+	sum := sha256.Sum256([]byte("42"))
+	mac := hmac.New(sha256.New, []byte("key"))
+	mac.Write([]byte("42"))
+
+	tt := []struct {
+		name   string
+		policy errorsx.RedactionPolicy
+		value  any
+		want   string
+	}{
+		{
+			name:  "default mask",
+			value: 42,
+			want:  errorsx.RedactedValue,
+		},
+		{
+			name:   "custom mask",
+			policy: errorsx.RedactionPolicy{Mask: "***"},
+			value:  42,
+			want:   "***",
+		},
+		{
+			name:   "hash",
+			policy: errorsx.RedactionPolicy{Mode: errorsx.RedactHash},
+			value:  42,
+			want:   "sha256:" + hex.EncodeToString(sum[:8]),
+		},
+		{
+			name:   "hash of sensitive value",
+			policy: errorsx.RedactionPolicy{Mode: errorsx.RedactHash},
+			value:  errorsx.Sensitive(42),
+			want:   "sha256:" + hex.EncodeToString(sum[:8]),
+		},
+		{
+			name:   "keyed hash",
+			policy: errorsx.RedactionPolicy{Mode: errorsx.RedactHash, HashKey: []byte("key")},
+			value:  42,
+			want:   "sha256:" + hex.EncodeToString(mac.Sum(nil)[:8]),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, tc.policy.Redact(tc.value))
+		})
+	}
+}
+
+func TestSensitive(t *testing.T) {
+	t.Parallel()
+	s := errorsx.Sensitive("s3cr3t")
+
+	assert.Equal(t, "s3cr3t", s.Value())
+	assert.Equal(t, errorsx.RedactedValue, fmt.Sprint(s))
+	assert.Equal(t, "map[token:[REDACTED]]", fmt.Sprint(map[string]any{"token": s}))
+
+	data, err := json.Marshal(map[string]any{"token": s})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"token":"[REDACTED]"}`, string(data))
+
+	var b strings.Builder
+	slog.New(slog.NewTextHandler(&b, nil)).Info("foo", "token", s)
+	assert.Contains(t, b.String(), "token=[REDACTED]")
+	assert.NotContains(t, b.String(), "s3cr3t")
+}
+
+func TestErrorX_Redaction(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name     string `validate:"required"`
+		Password string `validate:"min=8"`
+	}
+
+	validationErr := validator.New().Struct(User{Password: "hunter2"})
+
+	t.Run("sensitive value", func(t *testing.T) {
+		t.Parallel()
+		errX := &credentialErrorX{ErrorX: errorsx.New("foo"), user: "u1", token: errorsx.Sensitive("s3cr3t")}
+
+		got := errX.Fields("user", "token")
+		assert.Equal(t, map[string]any{"user": "u1", "token": errorsx.RedactedValue}, got)
+
+		data, err := errorsx.EncodeJSON(errX)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "s3cr3t")
+
+		decoded, err := errorsx.DecodeJSON(data)
+		require.NoError(t, err)
+		assert.Equal(t, got, decoded.Fields("user", "token"))
+	})
+
+	t.Run("sensitive key", func(t *testing.T) {
+		t.Parallel()
+		p := errorsx.RedactionPolicy{Keys: []string{"user"}, Mask: "***"}
+		errX := &credentialErrorX{
+			ErrorX: errorsx.New("foo", errorsx.WithRedactionPolicy(p)),
+			user:   "u1",
+			token:  errorsx.Sensitive("s3cr3t"),
+		}
+
+		got := errX.Fields("user", "token")
+		assert.Equal(t, map[string]any{"user": "***", "token": "***"}, got)
+	})
+
+	t.Run("nested under sensitive key", func(t *testing.T) {
+		t.Parallel()
+		p := errorsx.RedactionPolicy{Keys: []string{"auth"}}
+		errX := errorsx.New("foo", errorsx.WithRedactionPolicy(p)).
+			With("auth", map[string]any{"user": "bob", "pass": "hunter2"}).
+			With("tokens", map[string]any{"auth": []map[string]any{{"id": "t1"}}})
+
+		want := map[string]any{
+			"auth":   map[string]any{"user": errorsx.RedactedValue, "pass": errorsx.RedactedValue},
+			"tokens": map[string]any{"auth": []map[string]any{{"id": errorsx.RedactedValue}}},
+		}
+		assert.Equal(t, want, errX.Fields("auth", "tokens"))
+
+		data, err := json.Marshal(errX)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "hunter2")
+		assert.NotContains(t, string(data), "bob")
+		assert.NotContains(t, string(data), "t1")
+	})
+
+	t.Run("validation value", func(t *testing.T) {
+		t.Parallel()
+		p := errorsx.RedactionPolicy{Keys: []string{"*password*"}}
+		errX := errorsx.NewWithError(validationErr, "foo", errorsx.WithRedactionPolicy(p))
+
+		fieldErrs := validationErr.(validator.ValidationErrors)
+		want := map[string]any{
+			"User.Name":     []map[string]any{wantFieldEntry(fieldErrs[0], "")},
+			"User.Password": []map[string]any{wantFieldEntry(fieldErrs[1], errorsx.RedactedValue)},
+		}
+		assert.Equal(t, want, errX.Fields("validation_errors")["validation_errors"])
+
+		data, err := errorsx.EncodeJSON(errX)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "hunter2")
+	})
+}
+
+func TestSetRedactionPolicy(t *testing.T) {
+	defer errorsx.SetRedactionPolicy(errorsx.GetRedactionPolicy())
+
+	p := errorsx.RedactionPolicy{Mode: errorsx.RedactHash, Keys: []string{"user"}}
+	errorsx.SetRedactionPolicy(p)
+	assert.Equal(t, p, errorsx.GetRedactionPolicy())
+
+	errX := &credentialErrorX{ErrorX: errorsx.New("foo"), user: "u1"}
+	assert.Equal(t, p.Redact("u1"), errX.Fields("user")["user"])
+
+	errX.ErrorX = errorsx.New("foo", errorsx.WithRedactionPolicy(errorsx.RedactionPolicy{}))
+	assert.Equal(t, "u1", errX.Fields("user")["user"])
+}
@@ -0,0 +1,184 @@
+package retry
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caioreix/errorsx"
+)
+
+// retryErrorX is the outermost layer of the errors Do gives up with.
+type retryErrorX struct {
+	errorsx.ErrorX
+
+	attempts []Attempt
+}
+
+func (e *retryErrorX) Error() string {
+	return errorsx.Stringify(e)
+}
+
+func (e *retryErrorX) Unwrap() error {
+	return e.Inner()
+}
+
+func (e retryErrorX) Wrap(err error) errorsx.ErrorX {
+	e.ErrorX = e.ErrorX.Wrap(err)
+	return &e
+}
+
+func (e *retryErrorX) With(args ...any) errorsx.ErrorX {
+	return errorsx.With(e, args...)
+}
+
+func (e *retryErrorX) Fields(fields ...string) map[string]any {
+	return errorsx.Mapify(e, fields...)
+}
+
+func (e *retryErrorX) LayerMessage() string {
+	return ""
+}
+
+func (e *retryErrorX) LayerFields() map[string]any {
+	attempts := make([]map[string]any, len(e.attempts))
+	for i, a := range e.attempts {
+		attempts[i] = map[string]any{
+			"attempt":  a.Number,
+			"error":    a.Err.Error(),
+			"start":    a.Start,
+			"duration": a.Duration,
+			"delay":    a.Delay,
+		}
+	}
+
+	return map[string]any{"attempts": attempts}
+}
+
+func (e retryErrorX) Inner() errorsx.ErrorX {
+	return e.ErrorX
+}
+
+func (e *retryErrorX) MarshalJSON() ([]byte, error) {
+	return errorsx.EncodeJSON(e)
+}
+
+func (e *retryErrorX) Format(s fmt.State, verb rune) {
+	errorsx.Format(e, s, verb)
+}
+
+func (e *retryErrorX) LogValue() slog.Value {
+	return errorsx.LogValue(e)
+}
+
+// Attempts returns the attempts of the outermost error Do gave up with in
+// the chain of err.
+func Attempts(err error) ([]Attempt, bool) {
+	var re *retryErrorX
+	if !errorsx.As(err, &re) {
+		return nil, false
+	}
+
+	return re.attempts, true
+}
+
+// RetryAfterer is implemented by errors hinting how long to wait before
+// calling again, such as from a Retry-After header.
+type RetryAfterer interface {
+	RetryAfter() time.Duration
+}
+
+// WithRetryAfter decorates e with a hint to wait d before calling again.
+func WithRetryAfter(e errorsx.ErrorX, d time.Duration) errorsx.ErrorX {
+	return &retryAfterErrorX{ErrorX: e, after: d}
+}
+
+// RetryAfter returns the hint of the outermost RetryAfterer found in the
+// chain of err.
+func RetryAfter(err error) (time.Duration, bool) {
+	var ra RetryAfterer
+	if !errorsx.As(err, &ra) {
+		return 0, false
+	}
+
+	return ra.RetryAfter(), true
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, either a number
+// of seconds or an HTTP date, relative to now.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(t.Sub(now), 0), true
+}
+
+type retryAfterErrorX struct {
+	errorsx.ErrorX
+
+	after time.Duration
+}
+
+var _ RetryAfterer = (*retryAfterErrorX)(nil)
+
+func (e *retryAfterErrorX) Error() string {
+	return errorsx.Stringify(e)
+}
+
+func (e *retryAfterErrorX) Unwrap() error {
+	return e.Inner()
+}
+
+func (e retryAfterErrorX) Wrap(err error) errorsx.ErrorX {
+	e.ErrorX = e.ErrorX.Wrap(err)
+	return &e
+}
+
+func (e *retryAfterErrorX) With(args ...any) errorsx.ErrorX {
+	return errorsx.With(e, args...)
+}
+
+func (e *retryAfterErrorX) Fields(fields ...string) map[string]any {
+	return errorsx.Mapify(e, fields...)
+}
+
+func (e *retryAfterErrorX) RetryAfter() time.Duration {
+	return e.after
+}
+
+func (e *retryAfterErrorX) LayerMessage() string {
+	return "retry after " + e.after.String()
+}
+
+func (e *retryAfterErrorX) LayerFields() map[string]any {
+	return map[string]any{"retry_after": e.after}
+}
+
+func (e retryAfterErrorX) Inner() errorsx.ErrorX {
+	return e.ErrorX
+}
+
+func (e *retryAfterErrorX) MarshalJSON() ([]byte, error) {
+	return errorsx.EncodeJSON(e)
+}
+
+func (e *retryAfterErrorX) Format(s fmt.State, verb rune) {
+	errorsx.Format(e, s, verb)
+}
+
+func (e *retryAfterErrorX) LogValue() slog.Value {
+	return errorsx.LogValue(e)
+}
@@ -0,0 +1,81 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package retrymock
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RetryAfterer is an autogenerated mock type for the RetryAfterer type
+type RetryAfterer struct {
+	mock.Mock
+}
+
+type RetryAfterer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RetryAfterer) EXPECT() *RetryAfterer_Expecter {
+	return &RetryAfterer_Expecter{mock: &_m.Mock}
+}
+
+// RetryAfter provides a mock function with no fields
+func (_m *RetryAfterer) RetryAfter() time.Duration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryAfter")
+	}
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
+// RetryAfterer_RetryAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryAfter'
+type RetryAfterer_RetryAfter_Call struct {
+	*mock.Call
+}
+
+// RetryAfter is a helper method to define mock.On call
+func (_e *RetryAfterer_Expecter) RetryAfter() *RetryAfterer_RetryAfter_Call {
+	return &RetryAfterer_RetryAfter_Call{Call: _e.mock.On("RetryAfter")}
+}
+
+func (_c *RetryAfterer_RetryAfter_Call) Run(run func()) *RetryAfterer_RetryAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *RetryAfterer_RetryAfter_Call) Return(_a0 time.Duration) *RetryAfterer_RetryAfter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RetryAfterer_RetryAfter_Call) RunAndReturn(run func() time.Duration) *RetryAfterer_RetryAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRetryAfterer creates a new instance of RetryAfterer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRetryAfterer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RetryAfterer {
+	mock := &RetryAfterer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
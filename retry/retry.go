@@ -0,0 +1,242 @@
+// Package retry calls functions again while they fail with errors that
+// errorsx classifies as transient.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/caioreix/errorsx"
+)
+
+// Clock tells the time and waits. Tests replace the real one to run retries
+// without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Policy controls how often and how fast failed calls are retried.
+type Policy struct {
+	// MaxAttempts caps the number of calls, the first one included.
+	MaxAttempts int
+	// InitialDelay is the wait before the second call.
+	InitialDelay time.Duration
+	// MaxDelay caps the wait between two calls. Zero or less means no cap.
+	MaxDelay time.Duration
+	// Multiplier grows the wait after every call. Zero or less means 1, a
+	// constant wait.
+	Multiplier float64
+	// Jitter is the fraction, in [0, 1], of every wait that is randomly
+	// taken off to spread the calls of concurrent clients.
+	Jitter float64
+}
+
+// DefaultPolicy is used for every failure without a policy for its kind.
+var DefaultPolicy = Policy{
+	MaxAttempts:  3,
+	InitialDelay: 100 * time.Millisecond,
+	MaxDelay:     10 * time.Second,
+	Multiplier:   2,
+	Jitter:       0.2,
+}
+
+// delay returns the exponential backoff before the call following attempt,
+// before jitter.
+func (p Policy) delay(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	d := float64(p.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	switch {
+	case p.MaxDelay > 0 && d > float64(p.MaxDelay):
+		return p.MaxDelay
+	case d >= math.MaxInt64:
+		return math.MaxInt64
+	}
+
+	return time.Duration(d)
+}
+
+// Option configures Do.
+type Option func(*config)
+
+type config struct {
+	policy  Policy
+	kinds   map[errorsx.Kind]Policy
+	retryIf func(error) bool
+	clock   Clock
+	random  func() float64
+}
+
+// WithPolicy replaces DefaultPolicy.
+func WithPolicy(p Policy) Option {
+	return func(c *config) {
+		c.policy = p
+	}
+}
+
+// WithMaxAttempts overrides the MaxAttempts of the default policy.
+func WithMaxAttempts(n int) Option {
+	return func(c *config) {
+		c.policy.MaxAttempts = n
+	}
+}
+
+// WithBackoff overrides the delays of the default policy.
+func WithBackoff(initial, maxDelay time.Duration, multiplier float64) Option {
+	return func(c *config) {
+		c.policy.InitialDelay = initial
+		c.policy.MaxDelay = maxDelay
+		c.policy.Multiplier = multiplier
+	}
+}
+
+// WithJitter overrides the Jitter of the default policy.
+func WithJitter(jitter float64) Option {
+	return func(c *config) {
+		c.policy.Jitter = jitter
+	}
+}
+
+// WithKindPolicy retries the failures of kind k following p, even when k
+// isn't retryable by default.
+func WithKindPolicy(k errorsx.Kind, p Policy) Option {
+	return func(c *config) {
+		c.kinds[k] = p
+	}
+}
+
+// WithRetryIf replaces errorsx.IsRetryable in deciding whether a failure
+// without a kind policy is retried.
+func WithRetryIf(fn func(error) bool) Option {
+	return func(c *config) {
+		c.retryIf = fn
+	}
+}
+
+// WithClock replaces the real clock.
+func WithClock(clock Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// WithRandom replaces the source of the jitter, which must return numbers
+// in [0, 1).
+func WithRandom(fn func() float64) Option {
+	return func(c *config) {
+		c.random = fn
+	}
+}
+
+// Attempt describes one call made by Do.
+type Attempt struct {
+	// Number counts the calls from 1.
+	Number int
+	// Err is the error the call returned.
+	Err error
+	// Start is when the call was made.
+	Start time.Time
+	// Duration is how long the call took.
+	Duration time.Duration
+	// Delay is the wait before the next call, zero after the last one.
+	Delay time.Duration
+}
+
+// Do calls fn until it succeeds, fails with an error that isn't retried,
+// runs out of attempts or ctx is done. Failures are retried when their Kind
+// has a policy given by WithKindPolicy, or else when errorsx.IsRetryable
+// reports them, after an exponential backoff with jitter or the delay
+// hinted by RetryAfter.
+//
+// When the first call fails with an error that isn't retried, Do returns it
+// as is. Otherwise, when fn never succeeds, Do returns an ErrorX wrapping the
+// last error, whose Fields() list every attempt under "attempts". Attempts
+// returns them from the error.
+func Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	c := config{
+		policy:  DefaultPolicy,
+		kinds:   make(map[errorsx.Kind]Policy),
+		retryIf: errorsx.IsRetryable,
+		clock:   realClock{},
+		random:  rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var attempts []Attempt
+	for n := 1; ; n++ {
+		start := c.clock.Now()
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		attempts = append(attempts, Attempt{Number: n, Err: err, Start: start, Duration: c.clock.Now().Sub(start)})
+
+		p, ok := c.policyFor(err)
+		if !ok && n == 1 {
+			return err
+		}
+		if !ok || n >= p.MaxAttempts || ctx.Err() != nil {
+			return giveUp(attempts)
+		}
+
+		delay, hinted := RetryAfter(err)
+		if !hinted {
+			delay = p.delay(n)
+			delay -= time.Duration(p.Jitter * c.random() * float64(delay))
+		}
+		attempts[len(attempts)-1].Delay = delay
+
+		select {
+		case <-ctx.Done():
+			attempts[len(attempts)-1].Delay = 0
+			return giveUp(attempts, ctx.Err())
+		case <-c.clock.After(delay):
+		}
+	}
+}
+
+// policyFor returns the policy retrying err, reporting false when err isn't
+// retried.
+func (c *config) policyFor(err error) (Policy, bool) {
+	if kind, ok := errorsx.ErrorKind(err); ok {
+		if p, ok := c.kinds[kind]; ok {
+			return p, true
+		}
+	}
+
+	return c.policy, c.retryIf(err)
+}
+
+func giveUp(attempts []Attempt, errs ...error) error {
+	last := attempts[len(attempts)-1].Err
+	if len(errs) != 0 {
+		last = errors.Join(append([]error{last}, errs...)...)
+	}
+
+	msg := "retry: giving up after " + strconv.Itoa(len(attempts)) + " attempts"
+	if len(attempts) == 1 {
+		msg = "retry: giving up after 1 attempt"
+	}
+
+	// The error has no stack of its own: it would point at giveUp rather
+	// than at the failing call, which the wrapped error locates.
+	return &retryErrorX{
+		ErrorX:   errorsx.NewWithError(last, msg, errorsx.WithStackMode(errorsx.StackNone)),
+		attempts: attempts,
+	}
+}
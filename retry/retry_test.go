@@ -0,0 +1,343 @@
+package retry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caioreix/errorsx"
+	"github.com/caioreix/errorsx/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns from After at once, moving its time forward instead.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waits   []time.Duration
+	onAfter func()
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.waits = append(c.waits, d)
+	if c.onAfter != nil {
+		c.onAfter()
+		return nil
+	}
+
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// failing returns a function failing with errs in turn, then succeeding.
+func failing(errs ...error) (func(context.Context) error, *int) {
+	calls := 0
+	return func(context.Context) error {
+		calls++
+		if calls <= len(errs) {
+			return errs[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	unavailable := errorsx.NewKind(errorsx.KindUnavailable, "unavailable")
+	notFound := errorsx.NewKind(errorsx.KindNotFound, "not found")
+	conflict := errorsx.NewKind(errorsx.KindConflict, "conflict")
+
+	tt := []struct {
+		name      string
+		errs      []error
+		opts      []retry.Option
+		wantCalls int
+		wantWaits []time.Duration
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			wantCalls: 1,
+		},
+		{
+			name:      "success after retries",
+			errs:      []error{unavailable, unavailable},
+			wantCalls: 3,
+			wantWaits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:      "out of attempts",
+			errs:      []error{unavailable, unavailable, unavailable},
+			opts:      []retry.Option{retry.WithMaxAttempts(2)},
+			wantCalls: 2,
+			wantWaits: []time.Duration{100 * time.Millisecond},
+			wantErr:   unavailable,
+		},
+		{
+			name:      "not retryable",
+			errs:      []error{notFound},
+			wantCalls: 1,
+			wantErr:   notFound,
+		},
+		{
+			name:      "stdlib signal",
+			errs:      []error{fmt.Errorf("call: %w", context.DeadlineExceeded)},
+			wantCalls: 2,
+			wantWaits: []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name: "kind policy",
+			errs: []error{conflict, conflict, conflict},
+			opts: []retry.Option{retry.WithKindPolicy(errorsx.KindConflict, retry.Policy{
+				MaxAttempts:  3,
+				InitialDelay: time.Second,
+				MaxDelay:     time.Minute,
+				Multiplier:   3,
+			})},
+			wantCalls: 3,
+			wantWaits: []time.Duration{time.Second, 3 * time.Second},
+			wantErr:   conflict,
+		},
+		{
+			name:      "max delay",
+			errs:      []error{unavailable, unavailable, unavailable},
+			opts:      []retry.Option{retry.WithMaxAttempts(4), retry.WithBackoff(time.Second, 3*time.Second, 4)},
+			wantCalls: 4,
+			wantWaits: []time.Duration{time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:      "partial policy",
+			errs:      []error{unavailable, unavailable, unavailable},
+			opts:      []retry.Option{retry.WithPolicy(retry.Policy{MaxAttempts: 4, InitialDelay: time.Second, Multiplier: 2})},
+			wantCalls: 4,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:      "constant delay",
+			errs:      []error{unavailable, unavailable},
+			opts:      []retry.Option{retry.WithPolicy(retry.Policy{MaxAttempts: 3, InitialDelay: time.Second})},
+			wantCalls: 3,
+			wantWaits: []time.Duration{time.Second, time.Second},
+		},
+		{
+			name:      "Retry-After hint",
+			errs:      []error{retry.WithRetryAfter(errorsx.NewKind(errorsx.KindRateLimited, "slow down"), 5*time.Second)},
+			wantCalls: 2,
+			wantWaits: []time.Duration{5 * time.Second},
+		},
+		{
+			name:      "jitter",
+			errs:      []error{unavailable},
+			opts:      []retry.Option{retry.WithJitter(0.2), retry.WithRandom(func() float64 { return 0.5 })},
+			wantCalls: 2,
+			wantWaits: []time.Duration{90 * time.Millisecond},
+		},
+		{
+			name:      "retry if",
+			errs:      []error{notFound},
+			opts:      []retry.Option{retry.WithRetryIf(func(error) bool { return true })},
+			wantCalls: 2,
+			wantWaits: []time.Duration{100 * time.Millisecond},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			clock := newFakeClock()
+			fn, calls := failing(tc.errs...)
+
+			opts := append([]retry.Option{retry.WithClock(clock), retry.WithJitter(0)}, tc.opts...)
+			err := retry.Do(t.Context(), fn, opts...)
+
+			assert.Equal(t, tc.wantCalls, *calls)
+			assert.Equal(t, tc.wantWaits, clock.waits)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+
+	t.Run("not retryable returned as is", func(t *testing.T) {
+		t.Parallel()
+		fn, _ := failing(notFound)
+
+		err := retry.Do(t.Context(), fn, retry.WithClock(newFakeClock()))
+		assert.Same(t, notFound, err)
+
+		_, ok := retry.Attempts(err)
+		assert.False(t, ok)
+	})
+}
+
+func TestDo_Attempts(t *testing.T) {
+	t.Parallel()
+	clock := newFakeClock()
+	start := clock.Now()
+
+	errs := []error{
+		errorsx.NewKind(errorsx.KindTimeout, "timeout"),
+		errorsx.NewKind(errorsx.KindUnavailable, "unavailable"),
+	}
+	calls := 0
+	fn := func(context.Context) error {
+		clock.advance(30 * time.Millisecond)
+		calls++
+		return errs[calls-1]
+	}
+
+	err := retry.Do(t.Context(), fn, retry.WithClock(clock), retry.WithJitter(0), retry.WithMaxAttempts(2))
+	require.Error(t, err)
+
+	var errX errorsx.ErrorX
+	require.ErrorAs(t, err, &errX)
+	assert.Regexp(t, `^retry: giving up after 2 attempts: unavailable: kind unavailable: status 503 \[`, errX.Error())
+	assert.Equal(t, "retry: giving up after 2 attempts: unavailable: kind unavailable: status 503", fmt.Sprintf("%s", errX))
+	assert.Empty(t, errX.Caller())
+	assert.True(t, errorsx.IsKind(err, errorsx.KindUnavailable))
+	assert.Equal(t, slog.KindGroup, errX.(slog.LogValuer).LogValue().Kind())
+
+	data, jerr := json.Marshal(errX)
+	require.NoError(t, jerr)
+	assert.Contains(t, string(data), `"attempts":[{`)
+
+	want := []retry.Attempt{
+		{Number: 1, Err: errs[0], Start: start, Duration: 30 * time.Millisecond, Delay: 100 * time.Millisecond},
+		{Number: 2, Err: errs[1], Start: start.Add(130 * time.Millisecond), Duration: 30 * time.Millisecond},
+	}
+	got, ok := retry.Attempts(err)
+	require.True(t, ok)
+	assert.Equal(t, want, got)
+
+	assert.Equal(t, []map[string]any{
+		{
+			"attempt":  1,
+			"error":    errs[0].Error(),
+			"start":    start,
+			"duration": 30 * time.Millisecond,
+			"delay":    100 * time.Millisecond,
+		},
+		{
+			"attempt":  2,
+			"error":    errs[1].Error(),
+			"start":    start.Add(130 * time.Millisecond),
+			"duration": 30 * time.Millisecond,
+			"delay":    time.Duration(0),
+		},
+	}, errX.Fields("attempts")["attempts"])
+
+	_, ok = retry.Attempts(errors.New("foo"))
+	assert.False(t, ok)
+}
+
+func TestDo_Context(t *testing.T) {
+	t.Parallel()
+	unavailable := errorsx.NewKind(errorsx.KindUnavailable, "unavailable")
+
+	t.Run("canceled by the call", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(t.Context())
+		calls := 0
+
+		err := retry.Do(ctx, func(context.Context) error {
+			calls++
+			cancel()
+			return unavailable
+		}, retry.WithClock(newFakeClock()))
+
+		assert.Equal(t, 1, calls)
+		assert.ErrorIs(t, err, unavailable)
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(t.Context())
+		clock := newFakeClock()
+		clock.onAfter = cancel
+
+		err := retry.Do(ctx, func(context.Context) error { return unavailable }, retry.WithClock(clock))
+
+		assert.ErrorIs(t, err, unavailable)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		attempts, ok := retry.Attempts(err)
+		require.True(t, ok)
+		require.Len(t, attempts, 1)
+		assert.Zero(t, attempts[0].Delay)
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	errX := retry.WithRetryAfter(errorsx.NewHTTP(429, "slow down"), 2*time.Second)
+	assert.Regexp(t, `^slow down: retry after 2s: status 429 \[`, errX.Error())
+	assert.Equal(t, 2*time.Second, errX.Fields("retry_after")["retry_after"])
+	assert.Equal(t, "slow down: retry after 2s: status 429", fmt.Sprintf("%s", errX))
+	assert.Equal(t, slog.KindGroup, errX.(slog.LogValuer).LogValue().Kind())
+
+	data, err := json.Marshal(errX)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"retry_after":2000000000`)
+
+	got, ok := retry.RetryAfter(fmt.Errorf("call: %w", errX))
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, got)
+
+	_, ok = retry.RetryAfter(errors.New("foo"))
+	assert.False(t, ok)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "120", want: 2 * time.Minute, wantOK: true},
+		{value: " 0 ", want: 0, wantOK: true},
+		{value: "Mon, 01 Jan 2024 00:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{value: "Sun, 31 Dec 2023 23:00:00 GMT", want: 0, wantOK: true},
+		{value: "-1"},
+		{value: "soon"},
+	}
+
+	for _, tc := range tt {
+		got, ok := retry.ParseRetryAfter(tc.value, now)
+		assert.Equal(t, tc.wantOK, ok, tc.value)
+		assert.Equal(t, tc.want, got, tc.value)
+	}
+}
@@ -0,0 +1,176 @@
+package errorsx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SlogHandlerOptions configures a SlogHandler.
+type SlogHandlerOptions struct {
+	// MaxStackDepth limits the number of stack frames logged per error.
+	// Zero logs the whole stack and a negative value omits it.
+	MaxStackDepth int
+}
+
+// SlogHandler is a slog.Handler middleware that expands every error attribute
+// holding an ErrorX into a group with its message, caller, stack, status,
+// attributes and validation errors before passing the record on. Attributes
+// named after one of the other members, such as "message" or "status", are
+// left out so every key shows up once.
+type SlogHandler struct {
+	next slog.Handler
+	opts SlogHandlerOptions
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// slogReservedKeys are the members SlogHandler builds itself, which the
+// attributes of an error can't replace.
+var slogReservedKeys = []string{"message", "caller", "stack", "status", "validation"}
+
+// NewSlogHandler returns a SlogHandler that forwards records to next. A nil
+// opts is treated as the zero value.
+func NewSlogHandler(next slog.Handler, opts *SlogHandlerOptions) *SlogHandler {
+	h := &SlogHandler{next: next}
+	if opts != nil {
+		h.opts = *opts
+	}
+
+	return h
+}
+
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(h.expand(a))
+		return true
+	})
+
+	return h.next.Handle(ctx, nr)
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = h.expand(a)
+	}
+
+	return &SlogHandler{next: h.next.WithAttrs(expanded), opts: h.opts}
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return &SlogHandler{next: h.next.WithGroup(name), opts: h.opts}
+}
+
+func (h *SlogHandler) expand(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		expanded := make([]slog.Attr, len(attrs))
+		for i, ga := range attrs {
+			expanded[i] = h.expand(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(expanded...)}
+	case slog.KindAny, slog.KindLogValuer:
+		err, ok := a.Value.Any().(error)
+		if !ok {
+			return a
+		}
+
+		var ex ErrorX
+		if !errors.As(err, &ex) {
+			return a
+		}
+
+		return slog.Attr{Key: a.Key, Value: h.errorValue(err, ex)}
+	default:
+		return a
+	}
+}
+
+func (h *SlogHandler) errorValue(err error, ex ErrorX) slog.Value {
+	attrs := []slog.Attr{
+		slog.String("message", Message(err)),
+		slog.String("caller", ex.Caller()),
+	}
+
+	if h.opts.MaxStackDepth >= 0 {
+		stack := ex.Stack().Filter(GetStackFilter())
+		if h.opts.MaxStackDepth > 0 && len(stack) > h.opts.MaxStackDepth {
+			stack = stack[:h.opts.MaxStackDepth]
+		}
+
+		frames := make([]string, len(stack))
+		for i, sf := range stack {
+			frames[i] = sf.Function + " " + sf.File + ":" + strconv.Itoa(sf.Line)
+		}
+		attrs = append(attrs, slog.Any("stack", frames))
+	}
+
+	if status, ok := HTTPStatus(err); ok {
+		attrs = append(attrs, slog.Int("status", status))
+	}
+
+	errAttrs := redactionOf(ex).redactFields(Attributes(err))
+	for _, k := range slices.Sorted(maps.Keys(errAttrs)) {
+		if slices.Contains(slogReservedKeys, k) {
+			continue
+		}
+		attrs = append(attrs, slog.Any(k, errAttrs[k]))
+	}
+
+	if fieldErrs, ok := FieldErrors(err); ok {
+		var (
+			namespaces []string
+			tags       = make(map[string][]string)
+		)
+		for _, fe := range fieldErrs {
+			if _, ok := tags[fe.Namespace()]; !ok {
+				namespaces = append(namespaces, fe.Namespace())
+			}
+			tags[fe.Namespace()] = append(tags[fe.Namespace()], fe.Tag())
+		}
+
+		vattrs := make([]slog.Attr, 0, len(namespaces))
+		for _, ns := range namespaces {
+			vattrs = append(vattrs, slog.String(ns, strings.Join(tags[ns], ",")))
+		}
+		attrs = append(attrs, slog.Attr{Key: "validation", Value: slog.GroupValue(vattrs...)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// logValue builds the slog.Value every built-in layer returns from LogValue:
+// a group of the fields of e, sorted by key, with nested maps as groups.
+func logValue(e ErrorX) slog.Value {
+	return mapValue(mapify(e, nil))
+}
+
+func mapValue(m map[string]any) slog.Value {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		if nested, ok := m[k].(map[string]any); ok {
+			attrs = append(attrs, slog.Attr{Key: k, Value: mapValue(nested)})
+			continue
+		}
+		attrs = append(attrs, slog.Any(k, m[k]))
+	}
+
+	return slog.GroupValue(attrs...)
+}
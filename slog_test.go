@@ -0,0 +1,187 @@
+package errorsx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorX_LogValue(t *testing.T) {
+	t.Parallel()
+	var (
+		err    = fmt.Errorf("fake error")
+		msg    = "foo"
+		status = http.StatusUnprocessableEntity
+	)
+
+	errX := errorsx.NewHTTPWithError(err, status, msg)
+
+	lv, ok := errX.(slog.LogValuer)
+	require.True(t, ok)
+
+	got := lv.LogValue()
+	require.Equal(t, slog.KindGroup, got.Kind())
+
+	attrs := map[string]slog.Value{}
+	for _, a := range got.Group() {
+		attrs[a.Key] = a.Value
+	}
+	assert.Equal(t, msg, attrs["message"].String())
+	assert.Equal(t, err.Error(), attrs["error"].String())
+	assert.Equal(t, errX.Caller(), attrs["caller"].String())
+	assert.EqualValues(t, status, attrs["status"].Any())
+	assert.Equal(t, errX.Stack(), attrs["stack"].Any())
+}
+
+// tagsErrorX is a layer with value receivers holding a slice, which makes
+// its values uncomparable.
+type tagsErrorX struct {
+	errorsx.ErrorX
+
+	tags []string
+}
+
+func (e tagsErrorX) Error() string                     { return errorsx.Stringify(e) }
+func (e tagsErrorX) Unwrap() error                     { return e.ErrorX }
+func (e tagsErrorX) LayerMessage() string              { return "tags " + strings.Join(e.tags, ",") }
+func (e tagsErrorX) LayerFields() map[string]any       { return map[string]any{"tags": e.tags} }
+func (e tagsErrorX) Inner() errorsx.ErrorX             { return e.ErrorX }
+func (e tagsErrorX) Fields(f ...string) map[string]any { return errorsx.Mapify(e, f...) }
+
+func TestSlogHandler(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	validationErr := validator.New().Struct(User{})
+
+	logLine := func(t *testing.T, opts *errorsx.SlogHandlerOptions, log func(*slog.Logger)) map[string]any {
+		t.Helper()
+		var buf bytes.Buffer
+		log(slog.New(errorsx.NewSlogHandler(slog.NewJSONHandler(&buf, nil), opts)))
+
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		return got
+	}
+
+	t.Run("expands ErrorX", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewHTTPWithError(validationErr, http.StatusBadRequest, "foo")
+
+		got := logLine(t, nil, func(l *slog.Logger) { l.Error("failed", "err", errX) })
+
+		group, ok := got["err"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, fmt.Sprintf("foo: %s: status 400", validationErr.Error()), group["message"])
+		assert.Equal(t, errX.Caller(), group["caller"])
+		assert.EqualValues(t, http.StatusBadRequest, group["status"])
+		assert.Equal(t, map[string]any{"User.Name": "required"}, group["validation"])
+		assert.Len(t, group["stack"], len(errX.Stack()))
+	})
+
+	t.Run("expands wrapped ErrorX", func(t *testing.T) {
+		t.Parallel()
+		err := fmt.Errorf("bar: %w", errorsx.New("foo"))
+
+		got := logLine(t, nil, func(l *slog.Logger) { l.Error("failed", "err", err) })
+
+		group, ok := got["err"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "bar: foo", group["message"])
+	})
+
+	t.Run("expands attributes", func(t *testing.T) {
+		t.Parallel()
+		err := fmt.Errorf("bar: %w", errorsx.New("foo").With("user_id", 42, "token", errorsx.Sensitive("s3cr3t")))
+
+		got := logLine(t, nil, func(l *slog.Logger) { l.Error("failed", "err", err) })
+
+		group := got["err"].(map[string]any)
+		assert.EqualValues(t, 42, group["user_id"])
+		assert.Equal(t, errorsx.RedactedValue, group["token"])
+	})
+
+	t.Run("skips attributes named after members", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewHTTP(http.StatusBadRequest, "foo").With("message", "override", "status", 500, "user_id", 42)
+
+		var buf bytes.Buffer
+		slog.New(errorsx.NewSlogHandler(slog.NewJSONHandler(&buf, nil), nil)).Error("failed", "err", errX)
+
+		assert.Equal(t, 1, strings.Count(buf.String(), `"message":`))
+		assert.Equal(t, 1, strings.Count(buf.String(), `"status":`))
+
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		group := got["err"].(map[string]any)
+		assert.Equal(t, "foo: status 400", group["message"])
+		assert.EqualValues(t, http.StatusBadRequest, group["status"])
+		assert.EqualValues(t, 42, group["user_id"])
+	})
+
+	t.Run("limits stack depth", func(t *testing.T) {
+		t.Parallel()
+		opts := &errorsx.SlogHandlerOptions{MaxStackDepth: 1}
+
+		got := logLine(t, opts, func(l *slog.Logger) { l.Error("failed", "err", errorsx.New("foo")) })
+
+		group := got["err"].(map[string]any)
+		assert.Len(t, group["stack"], 1)
+	})
+
+	t.Run("omits stack", func(t *testing.T) {
+		t.Parallel()
+		opts := &errorsx.SlogHandlerOptions{MaxStackDepth: -1}
+
+		got := logLine(t, opts, func(l *slog.Logger) { l.Error("failed", "err", errorsx.New("foo")) })
+
+		group := got["err"].(map[string]any)
+		assert.NotContains(t, group, "stack")
+	})
+
+	t.Run("inside groups and WithAttrs", func(t *testing.T) {
+		t.Parallel()
+
+		got := logLine(t, nil, func(l *slog.Logger) {
+			l.With("base", errorsx.New("bar")).Error("failed", slog.Group("req", "err", errorsx.New("foo")))
+		})
+
+		base := got["base"].(map[string]any)
+		assert.Equal(t, "bar", base["message"])
+
+		req := got["req"].(map[string]any)
+		group := req["err"].(map[string]any)
+		assert.Equal(t, "foo", group["message"])
+	})
+
+	t.Run("value layer", func(t *testing.T) {
+		t.Parallel()
+		errX := tagsErrorX{ErrorX: errorsx.New("foo"), tags: []string{"a", "b"}}
+
+		got := logLine(t, nil, func(l *slog.Logger) { l.Error("failed", "err", errX) })
+
+		group := got["err"].(map[string]any)
+		assert.Equal(t, "foo: tags a,b", group["message"])
+	})
+
+	t.Run("leaves other errors untouched", func(t *testing.T) {
+		t.Parallel()
+
+		got := logLine(t, nil, func(l *slog.Logger) { l.Error("failed", "err", errors.New("foo")) })
+
+		assert.Equal(t, "foo", got["err"])
+	})
+}
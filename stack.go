@@ -2,8 +2,16 @@ package errorsx
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type StackFrame struct {
@@ -22,30 +30,275 @@ func (sf StackFrame) String() string {
 
 func (s Stack) String() string {
 	var sb strings.Builder
-	for _, sf := range s {
+	for _, sf := range s.Filter(GetStackFilter()) {
 		sb.WriteString(sf.String())
 	}
 	return sb.String()
 }
 
-func getStack(skip int) Stack {
-	buf := make([]uintptr, 32)
-	n := runtime.Callers(skip, buf[:])
-	stack := make([]uintptr, n)
-	copy(stack, buf[:n])
-
-	s := make(Stack, 0, n)
-	frames := runtime.CallersFrames(stack)
-	for {
-		frame, more := frames.Next()
-		s = append(s, &StackFrame{
-			Function: frame.Function,
-			File:     frame.File,
-			Line:     frame.Line,
-		})
-		if !more {
-			break
+// StackFilter rewrites a Stack for display. The zero value leaves it as is.
+type StackFilter struct {
+	// TrimGoPaths trims the GOROOT and GOPATH source prefixes, module cache
+	// included, from every frame file.
+	TrimGoPaths bool
+	// TrimPrefixes are trimmed from every frame file, such as the module
+	// root. The first matching prefix wins.
+	TrimPrefixes []string
+	// DropPrefixes drops every frame whose function starts with one of them,
+	// such as "runtime." or "net/http.".
+	DropPrefixes []string
+	// CollapsePackages keeps only the first of consecutive frames from the
+	// same package.
+	CollapsePackages bool
+}
+
+var stackFilter atomic.Pointer[StackFilter]
+
+// SetStackFilter sets the package-wide StackFilter applied by Stack.String()
+// and Fields().
+func SetStackFilter(f StackFilter) {
+	stackFilter.Store(&f)
+}
+
+// GetStackFilter returns the package-wide StackFilter.
+func GetStackFilter() StackFilter {
+	if f := stackFilter.Load(); f != nil {
+		return *f
+	}
+
+	return StackFilter{}
+}
+
+func (f StackFilter) isZero() bool {
+	return !f.TrimGoPaths && len(f.TrimPrefixes) == 0 && len(f.DropPrefixes) == 0 && !f.CollapsePackages
+}
+
+func (f StackFilter) trimPrefixes() []string {
+	if !f.TrimGoPaths {
+		return f.TrimPrefixes
+	}
+
+	return append(slices.Clone(f.TrimPrefixes), goPaths()...)
+}
+
+// goPaths returns the GOROOT and GOPATH source prefixes frame files start
+// with. The GOROOT one is taken from where the runtime was built, which
+// stays right when the binary runs on another machine.
+var goPaths = sync.OnceValue(func() []string {
+	var prefixes []string
+
+	frame, _ := runtime.CallersFrames([]uintptr{reflect.ValueOf(runtime.Gosched).Pointer()}).Next()
+	if goroot, ok := strings.CutSuffix(frame.File, "runtime/proc.go"); ok && goroot != "" {
+		prefixes = append(prefixes, goroot)
+	}
+
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			gopath = filepath.Join(home, "go")
+		}
+	}
+	for _, p := range filepath.SplitList(gopath) {
+		p = filepath.ToSlash(p)
+		prefixes = append(prefixes, p+"/pkg/mod/", p+"/src/")
+	}
+
+	return prefixes
+})
+
+// Filter returns a copy of s rewritten by f.
+func (s Stack) Filter(f StackFilter) Stack {
+	if f.isZero() {
+		return s
+	}
+
+	var (
+		trim     = f.trimPrefixes()
+		filtered = make(Stack, 0, len(s))
+		lastPkg  string
+	)
+	for _, sf := range s {
+		if slices.ContainsFunc(f.DropPrefixes, func(p string) bool {
+			return strings.HasPrefix(sf.Function, p)
+		}) {
+			continue
+		}
+
+		pkg := funcPackage(sf.Function)
+		if f.CollapsePackages && len(filtered) != 0 && pkg == lastPkg {
+			continue
+		}
+		lastPkg = pkg
+
+		frame := *sf
+		for _, p := range trim {
+			if file, ok := strings.CutPrefix(frame.File, p); ok {
+				frame.File = file
+				break
+			}
+		}
+		filtered = append(filtered, &frame)
+	}
+
+	return filtered
+}
+
+// funcPackage returns the import path of the package of a function name as
+// reported by runtime.Frame, e.g. "net/http" for "net/http.(*conn).serve".
+func funcPackage(function string) string {
+	slash := strings.LastIndexByte(function, '/') + 1
+	if dot := strings.IndexByte(function[slash:], '.'); dot >= 0 {
+		return function[:slash+dot]
+	}
+
+	return function
+}
+
+// StackMode selects how much of the call stack an error captures.
+type StackMode int
+
+const (
+	// StackFull captures up to StackPolicy.MaxDepth frames.
+	StackFull StackMode = iota
+	// StackCaller captures only the frame that created the error.
+	StackCaller
+	// StackNone captures nothing; Caller() is empty and Stack() is nil.
+	StackNone
+)
+
+const defaultMaxDepth = 32
+
+// StackPolicy controls stack capture when an error is created.
+type StackPolicy struct {
+	Mode StackMode
+	// MaxDepth caps the number of frames captured in StackFull mode. Zero
+	// means 32.
+	MaxDepth int
+	// SampleRate is the fraction of errors, in (0, 1], that capture a full
+	// stack in StackFull mode; the others capture only their caller. Zero
+	// means every error is sampled.
+	SampleRate float64
+}
+
+var stackPolicy atomic.Pointer[StackPolicy]
+
+// SetStackPolicy sets the package-wide StackPolicy used by every constructor
+// not given a stack option.
+func SetStackPolicy(p StackPolicy) {
+	stackPolicy.Store(&p)
+}
+
+// GetStackPolicy returns the package-wide StackPolicy.
+func GetStackPolicy() StackPolicy {
+	if p := stackPolicy.Load(); p != nil {
+		return *p
+	}
+
+	return StackPolicy{}
+}
+
+// depth returns the number of frames to capture under p.
+func (p StackPolicy) depth() int {
+	switch p.Mode {
+	case StackNone:
+		return 0
+	case StackCaller:
+		return 1
+	}
+
+	if p.SampleRate > 0 && p.SampleRate < 1 && rand.Float64() >= p.SampleRate {
+		return 1
+	}
+
+	if p.MaxDepth > 0 {
+		return p.MaxDepth
+	}
+
+	return defaultMaxDepth
+}
+
+// callers holds the program counters captured when an error is created and
+// resolves them into a caller and a Stack only when first asked for, caching
+// the result.
+type callers struct {
+	pcs []uintptr
+
+	callerOnce sync.Once
+	caller     string
+
+	stackOnce sync.Once
+	stack     Stack
+}
+
+// getCallers captures the program counters of the calling goroutine as
+// allowed by p, skipping skip frames the way runtime.Callers does. It returns
+// nil when p captures nothing.
+func getCallers(skip int, p StackPolicy) *callers {
+	depth := p.depth()
+	if depth == 0 {
+		return nil
+	}
+
+	var (
+		arr [defaultMaxDepth]uintptr
+		buf = arr[:]
+	)
+	if depth > len(buf) {
+		buf = make([]uintptr, depth)
+	}
+
+	n := runtime.Callers(skip+1, buf[:depth])
+
+	return &callers{pcs: slices.Clone(buf[:n])}
+}
+
+// resolvedCallers returns callers that were already resolved elsewhere, such
+// as the ones of a decoded error.
+func resolvedCallers(caller string, stack Stack) *callers {
+	c := &callers{}
+	c.callerOnce.Do(func() { c.caller = caller })
+	c.stackOnce.Do(func() { c.stack = stack })
+
+	return c
+}
+
+func (c *callers) Caller() string {
+	if c == nil {
+		return ""
+	}
+
+	c.callerOnce.Do(func() {
+		if len(c.pcs) == 0 {
+			return
 		}
+
+		frame, _ := runtime.CallersFrames(c.pcs[:1]).Next()
+		c.caller = frame.Function + " " + frame.File + ":" + strconv.Itoa(frame.Line)
+	})
+
+	return c.caller
+}
+
+func (c *callers) Stack() Stack {
+	if c == nil {
+		return nil
 	}
-	return s
+
+	c.stackOnce.Do(func() {
+		c.stack = make(Stack, 0, len(c.pcs))
+		frames := runtime.CallersFrames(c.pcs)
+		for {
+			frame, more := frames.Next()
+			c.stack = append(c.stack, &StackFrame{
+				Function: frame.Function,
+				File:     frame.File,
+				Line:     frame.Line,
+			})
+			if !more {
+				break
+			}
+		}
+	})
+
+	return c.stack
 }
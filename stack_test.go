@@ -1,10 +1,14 @@
 package errorsx_test
 
 import (
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/caioreix/errorsx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStackFrame_String(t *testing.T) {
@@ -117,3 +121,181 @@ func TestStack_String(t *testing.T) {
 		})
 	}
 }
+
+func TestStack_Filter(t *testing.T) {
+	t.Parallel()
+	stack := errorsx.Stack{
+		{Function: "github.com/foo/bar.(*Handler).ServeHTTP", File: "/src/bar/handler.go", Line: 10},
+		{Function: "github.com/foo/bar.middleware.func1", File: "/src/bar/middleware.go", Line: 20},
+		{Function: "github.com/foo/bar.middleware.func2", File: "/src/bar/middleware.go", Line: 30},
+		{Function: "net/http.HandlerFunc.ServeHTTP", File: "/usr/local/go/src/net/http/server.go", Line: 40},
+		{Function: "runtime.goexit", File: "/usr/local/go/src/runtime/asm_amd64.s", Line: 50},
+	}
+
+	tt := []struct {
+		name   string
+		filter errorsx.StackFilter
+		want   errorsx.Stack
+	}{
+		{
+			name:   "zero filter",
+			filter: errorsx.StackFilter{},
+			want:   stack,
+		},
+		{
+			name:   "trim prefixes",
+			filter: errorsx.StackFilter{TrimPrefixes: []string{"/usr/local/go/src/", "/src/"}},
+			want: errorsx.Stack{
+				{Function: "github.com/foo/bar.(*Handler).ServeHTTP", File: "bar/handler.go", Line: 10},
+				{Function: "github.com/foo/bar.middleware.func1", File: "bar/middleware.go", Line: 20},
+				{Function: "github.com/foo/bar.middleware.func2", File: "bar/middleware.go", Line: 30},
+				{Function: "net/http.HandlerFunc.ServeHTTP", File: "net/http/server.go", Line: 40},
+				{Function: "runtime.goexit", File: "runtime/asm_amd64.s", Line: 50},
+			},
+		},
+		{
+			name:   "drop prefixes",
+			filter: errorsx.StackFilter{DropPrefixes: []string{"runtime.", "net/http.", "github.com/foo/bar.middleware"}},
+			want: errorsx.Stack{
+				{Function: "github.com/foo/bar.(*Handler).ServeHTTP", File: "/src/bar/handler.go", Line: 10},
+			},
+		},
+		{
+			name:   "collapse packages",
+			filter: errorsx.StackFilter{CollapsePackages: true},
+			want: errorsx.Stack{
+				{Function: "github.com/foo/bar.(*Handler).ServeHTTP", File: "/src/bar/handler.go", Line: 10},
+				{Function: "net/http.HandlerFunc.ServeHTTP", File: "/usr/local/go/src/net/http/server.go", Line: 40},
+				{Function: "runtime.goexit", File: "/usr/local/go/src/runtime/asm_amd64.s", Line: 50},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := stack.Filter(tc.filter)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("trim go paths", func(t *testing.T) {
+		t.Parallel()
+		got := errorsx.New("foo").Stack().Filter(errorsx.StackFilter{TrimGoPaths: true})
+
+		require.NotEmpty(t, got)
+		assert.Equal(t, "runtime.goexit", got[len(got)-1].Function)
+		assert.True(t, strings.HasPrefix(got[len(got)-1].File, "runtime/"), got[len(got)-1].File)
+	})
+}
+
+func TestSetStackFilter(t *testing.T) {
+	defer errorsx.SetStackFilter(errorsx.GetStackFilter())
+	errorsx.SetStackFilter(errorsx.StackFilter{DropPrefixes: []string{"runtime.", "testing."}})
+
+	errX := errorsx.New("foo")
+	want := errX.Stack()[:1]
+
+	assert.Equal(t, want, errX.Fields()["stack"])
+	assert.Equal(t, want[0].String(), errX.Stack().String())
+}
+
+func TestErrorX_Stack(t *testing.T) {
+	t.Parallel()
+	errX := errorsx.New("foo")
+
+	got := errX.Stack()
+	require.NotEmpty(t, got)
+	assert.Same(t, got[0], errX.Stack()[0])
+
+	want := fmt.Sprintf("%s %s:%d", got[0].Function, got[0].File, got[0].Line)
+	assert.Equal(t, want, errX.Caller())
+}
+
+func TestStackPolicy_Options(t *testing.T) {
+	t.Parallel()
+	msg := "foo"
+
+	t.Run("StackNone", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.New(msg, errorsx.WithStackMode(errorsx.StackNone))
+		assert.Empty(t, errX.Caller())
+		assert.Nil(t, errX.Stack())
+		assert.Equal(t, msg, errX.Error())
+	})
+
+	t.Run("StackCaller", func(t *testing.T) {
+		t.Parallel()
+		rx := callerRX(fmt.Sprintf("%s: status %d", msg, http.StatusNotFound))
+		errX := errorsx.NewHTTP(http.StatusNotFound, msg, errorsx.WithStackMode(errorsx.StackCaller))
+		assert.Regexp(t, rx, errX.Error())
+		assert.Len(t, errX.Stack(), 1)
+	})
+
+	t.Run("WithStackDepth", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewWithError(fmt.Errorf("fake error"), msg, errorsx.WithStackDepth(2))
+		assert.Len(t, errX.Stack(), 2)
+	})
+
+	t.Run("WithStackPolicy", func(t *testing.T) {
+		t.Parallel()
+		policy := errorsx.StackPolicy{Mode: errorsx.StackFull, SampleRate: 1e-12}
+		errX := errorsx.NewHTTPWithError(fmt.Errorf("fake error"), http.StatusNotFound, msg, errorsx.WithStackPolicy(policy))
+		assert.Len(t, errX.Stack(), 1)
+	})
+}
+
+func TestSetStackPolicy(t *testing.T) {
+	defer errorsx.SetStackPolicy(errorsx.GetStackPolicy())
+
+	errorsx.SetStackPolicy(errorsx.StackPolicy{Mode: errorsx.StackNone})
+	assert.Nil(t, errorsx.New("foo").Stack())
+	assert.Len(t, errorsx.New("foo", errorsx.WithStackMode(errorsx.StackCaller)).Stack(), 1)
+
+	errorsx.SetStackPolicy(errorsx.StackPolicy{MaxDepth: 1})
+	assert.Len(t, errorsx.Newf("foo %s", "bar").Stack(), 1)
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = errorsx.New("foo")
+	}
+}
+
+func BenchmarkNewHTTP(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = errorsx.NewHTTP(http.StatusNotFound, "foo")
+	}
+}
+
+func BenchmarkNewHTTP_StackNone(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = errorsx.NewHTTP(http.StatusNotFound, "foo", errorsx.WithStackMode(errorsx.StackNone))
+	}
+}
+
+func BenchmarkNew_Error(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = errorsx.New("foo").Error()
+	}
+}
+
+func BenchmarkNew_Stack(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = errorsx.New("foo").Stack()
+	}
+}
+
+func BenchmarkErrorX_Stack(b *testing.B) {
+	errX := errorsx.New("foo")
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = errX.Stack()
+	}
+}
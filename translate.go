@@ -0,0 +1,114 @@
+package errorsx
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+var universalTranslator atomic.Pointer[ut.UniversalTranslator]
+
+// SetUniversalTranslator sets the package-wide translators WithContextLocale
+// picks from. The validator translations must be registered on them.
+func SetUniversalTranslator(uni *ut.UniversalTranslator) {
+	universalTranslator.Store(uni)
+}
+
+// GetUniversalTranslator returns the package-wide translators, or nil when
+// none were set.
+func GetUniversalTranslator() *ut.UniversalTranslator {
+	return universalTranslator.Load()
+}
+
+type localeKey struct{}
+
+// ContextWithLocale returns a copy of ctx carrying locale, such as the one
+// negotiated from the Accept-Language header of a request.
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale carried by ctx.
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeKey{}).(string)
+	return locale, ok
+}
+
+// WithTranslator makes the error render its validation failures with trans.
+func WithTranslator(trans ut.Translator) Option {
+	return func(o *options) {
+		o.translator = trans
+	}
+}
+
+// WithContextLocale makes the error render its validation failures with the
+// package-wide translator of the locale carried by ctx, falling back to the
+// default locale of the UniversalTranslator. It does nothing when ctx carries
+// no locale or no UniversalTranslator was set.
+func WithContextLocale(ctx context.Context) Option {
+	return func(o *options) {
+		uni := GetUniversalTranslator()
+		locale, ok := LocaleFromContext(ctx)
+		if uni == nil || !ok {
+			return
+		}
+
+		o.translator, _ = uni.GetTranslator(locale)
+	}
+}
+
+// renderError renders err with the validation failures it holds, directly,
+// joined or wrapped, translated by trans when given, and with the ErrorX
+// values it holds rendered without their caller, as %s does, when bare is
+// set. A wrapper keeps the text it adds before the message of the error it
+// wraps, such as the "bind: " of fmt.Errorf("bind: %w", err).
+func renderError(err error, trans ut.Translator, bare bool) string {
+	if trans == nil && !bare {
+		return err.Error()
+	}
+
+	switch et := err.(type) {
+	case ErrorX:
+		if !bare {
+			return et.Error()
+		}
+		msg, _ := bareMessages(et)
+		return msg
+	case validator.ValidationErrors:
+		if trans == nil {
+			return err.Error()
+		}
+		msgs := make([]string, len(et))
+		for i, fe := range et {
+			msgs[i] = fe.Translate(trans)
+		}
+		return strings.Join(msgs, "; ")
+	case interface{ Unwrap() []error }:
+		errs := et.Unwrap()
+		msgs := make([]string, 0, len(errs))
+		texts := make([]string, 0, len(errs))
+		for _, e := range errs {
+			msgs = append(msgs, renderError(e, trans, bare))
+			texts = append(texts, e.Error())
+		}
+		if err.Error() != strings.Join(texts, "\n") {
+			return err.Error()
+		}
+		return strings.Join(msgs, "\n")
+	case interface{ Unwrap() error }:
+		inner := et.Unwrap()
+		if inner == nil {
+			return err.Error()
+		}
+		prefix, ok := strings.CutSuffix(err.Error(), inner.Error())
+		if !ok {
+			return err.Error()
+		}
+		return prefix + renderError(inner, trans, bare)
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,144 @@
+package errorsx_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/pt_BR"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	pt_BR_translations "github.com/go-playground/validator/v10/translations/pt_BR"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTranslators returns translators for en and pt_BR registered on a new
+// validator, along with the validation errors of an empty User.
+func newTranslators(t *testing.T) (*ut.UniversalTranslator, error) {
+	t.Helper()
+
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	uni := ut.New(en.New(), en.New(), pt_BR.New())
+	validate := validator.New()
+
+	enTrans, _ := uni.GetTranslator("en")
+	require.NoError(t, en_translations.RegisterDefaultTranslations(validate, enTrans))
+	ptTrans, _ := uni.GetTranslator("pt_BR")
+	require.NoError(t, pt_BR_translations.RegisterDefaultTranslations(validate, ptTrans))
+
+	return uni, validate.Struct(User{})
+}
+
+func TestWithTranslator(t *testing.T) {
+	t.Parallel()
+	uni, validationErr := newTranslators(t)
+	trans, _ := uni.GetTranslator("en")
+
+	wantField := []map[string]any{{
+		"tag":              "required",
+		"param":            "",
+		"value":            "",
+		"namespace":        "User.Name",
+		"struct_namespace": "User.Name",
+		"message":          "Name is a required field",
+	}}
+
+	t.Run("NewWithError", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewWithError(validationErr, "foo", errorsx.WithTranslator(trans))
+
+		assert.Regexp(t, callerRX("foo: Name is a required field"), errX.Error())
+		assert.Equal(t, map[string]any{
+			"error":             "Name is a required field",
+			"validation_errors": map[string]any{"User.Name": wantField},
+		}, errX.Fields("error", "validation_errors"))
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewHTTP(400, "foo", errorsx.WithTranslator(trans)).Wrap(validationErr)
+
+		assert.Regexp(t, callerRX("foo: Name is a required field: status 400"), errX.Error())
+		assert.Equal(t, map[string]any{"User.Name": wantField}, errX.Fields("validation_errors")["validation_errors"])
+	})
+
+	t.Run("joined", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.New("foo", errorsx.WithTranslator(trans)).
+			Wrap(validationErr).
+			Wrap(fmt.Errorf("bar"))
+
+		assert.Regexp(t, callerRX("foo: Name is a required field\nbar"), errX.Error())
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewWithError(fmt.Errorf("bind: %w", validationErr), "foo", errorsx.WithTranslator(trans))
+
+		assert.Regexp(t, callerRX("foo: bind: Name is a required field"), errX.Error())
+		assert.Equal(t, "bind: Name is a required field", errX.Fields("error")["error"])
+	})
+}
+
+func TestWithContextLocale(t *testing.T) {
+	defer errorsx.SetUniversalTranslator(errorsx.GetUniversalTranslator())
+	uni, validationErr := newTranslators(t)
+
+	tt := []struct {
+		name    string
+		uni     *ut.UniversalTranslator
+		ctx     context.Context
+		wantMsg string
+	}{
+		{
+			name:    "locale",
+			uni:     uni,
+			ctx:     errorsx.ContextWithLocale(context.Background(), "pt_BR"),
+			wantMsg: "Name é um campo obrigatório",
+		},
+		{
+			name:    "unknown locale",
+			uni:     uni,
+			ctx:     errorsx.ContextWithLocale(context.Background(), "xx"),
+			wantMsg: "Name is a required field",
+		},
+		{
+			name:    "no locale",
+			uni:     uni,
+			ctx:     context.Background(),
+			wantMsg: validationErr.Error(),
+		},
+		{
+			name:    "no UniversalTranslator",
+			ctx:     errorsx.ContextWithLocale(context.Background(), "pt_BR"),
+			wantMsg: validationErr.Error(),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			errorsx.SetUniversalTranslator(tc.uni)
+			errX := errorsx.NewWithError(validationErr, "foo", errorsx.WithContextLocale(tc.ctx))
+
+			assert.Equal(t, tc.wantMsg, errX.Fields("error")["error"])
+		})
+	}
+}
+
+func TestLocaleFromContext(t *testing.T) {
+	t.Parallel()
+
+	locale, ok := errorsx.LocaleFromContext(errorsx.ContextWithLocale(context.Background(), "pt_BR"))
+	assert.True(t, ok)
+	assert.Equal(t, "pt_BR", locale)
+
+	_, ok = errorsx.LocaleFromContext(context.Background())
+	assert.False(t, ok)
+}
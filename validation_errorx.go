@@ -1,19 +1,93 @@
 package errorsx
 
-import "github.com/go-playground/validator/v10"
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// validationErrorsKey is the Fields() key of the validation failures.
+const validationErrorsKey = "validation_errors"
+
+// ValidationMode selects how Fields() lays out validation failures under
+// "validation_errors".
+type ValidationMode int
+
+const (
+	// ValidationFlat keys failures by their full namespace, such as
+	// "User.Items[0].Name".
+	ValidationFlat ValidationMode = iota
+	// ValidationNested nests failures in maps following their namespace
+	// without the top-level struct, such as {"Items": {"0": {"Name": ...}}}.
+	// Failures of a field that also has failing children go under "_errors".
+	ValidationNested
+	// ValidationJSONPointer keys failures by the RFC 6901 JSON pointer of
+	// their namespace without the top-level struct, such as "/Items/0/Name".
+	ValidationJSONPointer
+)
+
+// RedactedValue replaces the offending values of redacted validation
+// failures.
+const RedactedValue = "[REDACTED]"
+
+// ValidationPolicy controls how validation failures are rendered by Fields().
+// Every field maps to the list of its failures, each with its tag, param,
+// value, namespaces and message.
+type ValidationPolicy struct {
+	Mode ValidationMode
+	// RedactValues replaces the offending values with RedactedValue.
+	RedactValues bool
+}
+
+var validationPolicy atomic.Pointer[ValidationPolicy]
+
+// SetValidationPolicy sets the package-wide ValidationPolicy used by every
+// constructor not given WithValidationPolicy.
+func SetValidationPolicy(p ValidationPolicy) {
+	validationPolicy.Store(&p)
+}
+
+// GetValidationPolicy returns the package-wide ValidationPolicy.
+func GetValidationPolicy() ValidationPolicy {
+	if p := validationPolicy.Load(); p != nil {
+		return *p
+	}
+
+	return ValidationPolicy{}
+}
+
+// validationOptions holds what an error needs to render its validation
+// failures, captured when it is created.
+type validationOptions struct {
+	translator ut.Translator
+	policy     ValidationPolicy
+}
+
+// FieldErrorer is implemented by errors that carry validation failures.
+type FieldErrorer interface {
+	FieldErrors() validator.ValidationErrors
+}
 
 type validationErrorX struct {
 	ErrorX
 
 	fieldErrors validator.ValidationErrors
+	validation  validationOptions
 }
 
+var _ FieldErrorer = (*validationErrorX)(nil)
+
 func (e *validationErrorX) Error() string {
 	return stringify(e)
 }
 
 func (e *validationErrorX) Unwrap() error {
-	return e.unwrap()
+	return e.Inner()
 }
 
 func (e validationErrorX) Wrap(err error) ErrorX {
@@ -21,7 +95,15 @@ func (e validationErrorX) Wrap(err error) ErrorX {
 	return &e
 }
 
-func (e *validationErrorX) string() string {
+func (e *validationErrorX) With(args ...any) ErrorX {
+	return With(e, args...)
+}
+
+func (e *validationErrorX) FieldErrors() validator.ValidationErrors {
+	return e.fieldErrors
+}
+
+func (e *validationErrorX) LayerMessage() string {
 	return ""
 }
 
@@ -29,11 +111,11 @@ func (e *validationErrorX) Fields(fields ...string) map[string]any {
 	return mapify(e, fields)
 }
 
-func (e validationErrorX) unwrap() ErrorX {
+func (e validationErrorX) Inner() ErrorX {
 	return e.ErrorX
 }
 
-func (e *validationErrorX) fields() map[string]any {
+func (e *validationErrorX) LayerFields() map[string]any {
 	m := map[string]any{}
 
 	if e.fieldErrors == nil {
@@ -41,13 +123,249 @@ func (e *validationErrorX) fields() map[string]any {
 	}
 
 	errs := make(map[string]any)
-	for _, err := range e.fieldErrors {
-		errs[err.Field()] = err.Tag()
+	for _, fe := range e.fieldErrors {
+		entry := e.fieldEntry(fe)
+		switch e.validation.policy.Mode {
+		case ValidationNested:
+			nestFieldEntry(errs, pathSegments(fe), entry)
+		case ValidationJSONPointer:
+			ptr := jsonPointer(pathSegments(fe))
+			errs[ptr] = appendEntry(errs[ptr], entry)
+		default:
+			errs[fe.Namespace()] = appendEntry(errs[fe.Namespace()], entry)
+		}
 	}
 
 	if len(errs) != 0 {
-		m["validation_errors"] = errs
+		m[validationErrorsKey] = errs
 	}
 
 	return m
 }
+
+func (e *validationErrorX) fieldEntry(fe validator.FieldError) map[string]any {
+	value := fe.Value()
+	if e.validation.policy.RedactValues || redactionOf(e).sensitiveField(fe) {
+		value = Sensitive(value)
+	}
+
+	return map[string]any{
+		"tag":              fe.Tag(),
+		"param":            fe.Param(),
+		"value":            value,
+		"namespace":        fe.Namespace(),
+		"struct_namespace": fe.StructNamespace(),
+		"message":          fe.Translate(e.validation.translator),
+	}
+}
+
+func appendEntry(entries any, entry map[string]any) []map[string]any {
+	list, _ := entries.([]map[string]any)
+	return append(list, entry)
+}
+
+// nestFieldEntry adds entry to the list found at path in m, creating the
+// intermediate maps.
+func nestFieldEntry(m map[string]any, path []string, entry map[string]any) {
+	for i, seg := range path {
+		last := i == len(path)-1
+		switch v := m[seg].(type) {
+		case map[string]any:
+			if last {
+				v["_errors"] = appendEntry(v["_errors"], entry)
+				return
+			}
+			m = v
+		case []map[string]any:
+			if last {
+				m[seg] = append(v, entry)
+				return
+			}
+			child := map[string]any{"_errors": v}
+			m[seg] = child
+			m = child
+		default:
+			if last {
+				m[seg] = []map[string]any{entry}
+				return
+			}
+			child := map[string]any{}
+			m[seg] = child
+			m = child
+		}
+	}
+}
+
+// FieldPath returns the path locating fe, such as "Items[0].Name" for the
+// validator namespace "User.Items[0].Name": its namespace with the top-level
+// struct left out, in the form of FieldViolation.Path.
+func FieldPath(fe validator.FieldError) string {
+	if _, ok := fe.(interface{ Path() []string }); ok {
+		return fe.Namespace()
+	}
+
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+
+	return fe.Namespace()
+}
+
+// pathSegments returns the segments locating fe, such as Items, 0 and Name
+// for the validator namespace "User.Items[0].Name", which has its top-level
+// struct left out.
+func pathSegments(fe validator.FieldError) []string {
+	if p, ok := fe.(interface{ Path() []string }); ok {
+		return p.Path()
+	}
+
+	path := splitNamespace(fe.Namespace())
+	if len(path) > 1 {
+		path = path[1:]
+	}
+
+	return path
+}
+
+// splitNamespace splits a namespace such as "User.Items[0].Name" into its
+// segments: User, Items, 0 and Name.
+func splitNamespace(ns string) []string {
+	var path []string
+	for _, part := range strings.Split(ns, ".") {
+		name, keys, _ := strings.Cut(part, "[")
+		path = append(path, name)
+		for keys != "" {
+			key, rest, _ := strings.Cut(keys, "]")
+			path = append(path, key)
+			keys = strings.TrimPrefix(rest, "[")
+		}
+	}
+
+	return path
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(path []string) string {
+	var b strings.Builder
+	for _, seg := range path {
+		b.WriteByte('/')
+		b.WriteString(jsonPointerEscaper.Replace(seg))
+	}
+
+	return b.String()
+}
+
+func (e *validationErrorX) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *validationErrorX) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *validationErrorX) MarshalText() ([]byte, error) {
+	return EncodeJSON(e)
+}
+
+func (e *validationErrorX) UnmarshalText(data []byte) error {
+	return unmarshalInto(e, DecodeJSON, data)
+}
+
+func (e *validationErrorX) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(e)
+}
+
+func (e *validationErrorX) UnmarshalBinary(data []byte) error {
+	return unmarshalInto(e, DecodeBinary, data)
+}
+
+func (e *validationErrorX) Format(s fmt.State, verb rune) {
+	format(e, s, verb)
+}
+
+func (e *validationErrorX) LogValue() slog.Value {
+	return logValue(e)
+}
+
+func (e *validationErrorX) encodeLayer() jsonLayer {
+	l := jsonLayer{Type: layerTypeValidation}
+	p := redactionOf(e)
+	for _, fe := range e.fieldErrors {
+		jfe := encodeFieldError(fe)
+		if e.validation.policy.RedactValues || p.sensitiveField(fe) {
+			jfe.Value, _ = json.Marshal(p.Redact(fe.Value()))
+		}
+		l.ValidationErrors = append(l.ValidationErrors, jfe)
+	}
+
+	return l
+}
+
+// FieldErrors returns the validation failures of every FieldErrorer found in
+// the chain of err, including errors.Join branches, merged outermost first.
+// It reports false when the chain holds no validation failure.
+func FieldErrors(err error) (validator.ValidationErrors, bool) {
+	var errs validator.ValidationErrors
+
+	walk(err, func(e error) bool {
+		if fe, ok := e.(FieldErrorer); ok {
+			errs = append(errs, fe.FieldErrors()...)
+		}
+		return true
+	})
+
+	return errs, len(errs) != 0
+}
+
+// FieldMessages returns the messages of the validation failures FieldErrors
+// returns, in the same order, each translated by the translator the error
+// holding it was created with.
+func FieldMessages(err error) []string {
+	var msgs []string
+
+	walk(err, func(e error) bool {
+		fe, ok := e.(FieldErrorer)
+		if !ok {
+			return true
+		}
+
+		var trans ut.Translator
+		if ve, ok := e.(*validationErrorX); ok {
+			trans = ve.validation.translator
+		}
+		for _, f := range fe.FieldErrors() {
+			msgs = append(msgs, f.Translate(trans))
+		}
+		return true
+	})
+
+	return msgs
+}
+
+// validationErrors merges the validator.ValidationErrors found in the chain
+// of err, including errors.Join branches, and reports whether there was any.
+// FieldErrorers are not descended into: they already expose their failures.
+func validationErrors(err error) (validator.ValidationErrors, bool) {
+	switch e := err.(type) {
+	case nil, FieldErrorer:
+		return nil, false
+	case validator.ValidationErrors:
+		return e, true
+	case interface{ Unwrap() []error }:
+		var (
+			errs  validator.ValidationErrors
+			found bool
+		)
+		for _, u := range e.Unwrap() {
+			if ue, ok := validationErrors(u); ok {
+				errs, found = append(errs, ue...), true
+			}
+		}
+		return errs, found
+	case interface{ Unwrap() error }:
+		return validationErrors(e.Unwrap())
+	default:
+		return nil, false
+	}
+}
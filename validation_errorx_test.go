@@ -1,6 +1,9 @@
 package errorsx_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -8,6 +11,7 @@ import (
 	"github.com/caioreix/errorsx"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidationErrorX_Wrap(t *testing.T) {
@@ -141,6 +145,28 @@ func TestValidationErrorX_NewWithWrap(t *testing.T) {
 	})
 }
 
+// wantFieldErrors builds the "validation_errors" field expected for errs
+// when no translator is given.
+func wantFieldErrors(err error) map[string]any {
+	want := make(map[string]any)
+	for _, fe := range err.(validator.ValidationErrors) {
+		want[fe.Namespace()] = []map[string]any{wantFieldEntry(fe, fe.Value())}
+	}
+
+	return want
+}
+
+func wantFieldEntry(fe validator.FieldError, value any) map[string]any {
+	return map[string]any{
+		"tag":              fe.Tag(),
+		"param":            fe.Param(),
+		"value":            value,
+		"namespace":        fe.Namespace(),
+		"struct_namespace": fe.StructNamespace(),
+		"message":          fe.Error(),
+	}
+}
+
 func TestValidationErrorX_Fields(t *testing.T) {
 	type User struct {
 		Name  string `validate:"required"`
@@ -160,12 +186,9 @@ func TestValidationErrorX_Fields(t *testing.T) {
 			msg = "foo"
 
 			want = map[string]any{
-				"message": msg,
-				"error":   err.Error(),
-				"validation_errors": map[string]any{
-					"Email": "email",
-					"Name":  "required",
-				},
+				"message":           msg,
+				"error":             err.Error(),
+				"validation_errors": wantFieldErrors(err),
 			}
 		)
 
@@ -185,13 +208,10 @@ func TestValidationErrorX_Fields(t *testing.T) {
 			status = http.StatusBadRequest
 
 			want = map[string]any{
-				"message": msg,
-				"error":   err.Error(),
-				"status":  status,
-				"validation_errors": map[string]any{
-					"Email": "email",
-					"Name":  "required",
-				},
+				"message":           msg,
+				"error":             err.Error(),
+				"status":            status,
+				"validation_errors": wantFieldErrors(err),
 			}
 		)
 
@@ -210,11 +230,8 @@ func TestValidationErrorX_Fields(t *testing.T) {
 			msg = "foo"
 
 			want = map[string]any{
-				"message": msg,
-				"validation_errors": map[string]any{
-					"Email": "email",
-					"Name":  "required",
-				},
+				"message":           msg,
+				"validation_errors": wantFieldErrors(err),
 			}
 		)
 
@@ -263,3 +280,251 @@ func TestValidationErrorX_Fields(t *testing.T) {
 		assert.Equal(t, want, got)
 	})
 }
+
+func TestFieldErrors(t *testing.T) {
+	type User struct {
+		Name  string `validate:"required"`
+		Email string `validate:"required,email"`
+	}
+
+	validate := validator.New()
+	validationErr1 := validate.Struct(User{Email: "x"}).(validator.ValidationErrors)
+	validationErr2 := validate.Struct(User{Name: "x"}).(validator.ValidationErrors)
+
+	t.Run("without validation errors", func(t *testing.T) {
+		t.Parallel()
+		got, ok := errorsx.FieldErrors(errorsx.New("foo"))
+		assert.False(t, ok)
+		assert.Empty(t, got)
+	})
+
+	t.Run("ErrorX", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewWithError(validationErr1, "foo")
+
+		fe, ok := errX.(errorsx.FieldErrorer)
+		assert.True(t, ok)
+		assert.Equal(t, validationErr1, fe.FieldErrors())
+
+		got, ok := errorsx.FieldErrors(errX)
+		assert.True(t, ok)
+		assert.Equal(t, validationErr1, got)
+	})
+
+	t.Run("HTTPErrorX wrapped by fmt.Errorf", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewHTTPWithError(validationErr1, http.StatusBadRequest, "foo")
+
+		got, ok := errorsx.FieldErrors(fmt.Errorf("bar: %w", errX))
+		assert.True(t, ok)
+		assert.Equal(t, validationErr1, got)
+	})
+
+	t.Run("merged through Wrap", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewWithError(validationErr1, "foo").Wrap(validationErr2)
+
+		got, ok := errorsx.FieldErrors(errX)
+		assert.True(t, ok)
+		assert.ElementsMatch(t, append(validationErr1, validationErr2...), got)
+	})
+}
+
+func TestValidationErrorX_ValidationPolicy(t *testing.T) {
+	t.Parallel()
+
+	type Item struct {
+		Name string `validate:"required,min=3"`
+	}
+
+	type Order struct {
+		Items []Item `validate:"min=1,dive"`
+		Note  string `validate:"max=2"`
+	}
+
+	validate := validator.New()
+	validationErr := validate.Struct(Order{
+		Items: []Item{{Name: ""}, {Name: "ab"}},
+		Note:  "abc",
+	}).(validator.ValidationErrors)
+	require.Len(t, validationErr, 3)
+
+	var (
+		name0 = validationErr[0]
+		name1 = validationErr[1]
+		note  = validationErr[2]
+	)
+
+	tt := []struct {
+		name   string
+		policy errorsx.ValidationPolicy
+		want   map[string]any
+	}{
+		{
+			name: "flat",
+			want: map[string]any{
+				"Order.Items[0].Name": []map[string]any{wantFieldEntry(name0, "")},
+				"Order.Items[1].Name": []map[string]any{wantFieldEntry(name1, "ab")},
+				"Order.Note":          []map[string]any{wantFieldEntry(note, "abc")},
+			},
+		},
+		{
+			name:   "nested",
+			policy: errorsx.ValidationPolicy{Mode: errorsx.ValidationNested},
+			want: map[string]any{
+				"Items": map[string]any{
+					"0": map[string]any{"Name": []map[string]any{wantFieldEntry(name0, "")}},
+					"1": map[string]any{"Name": []map[string]any{wantFieldEntry(name1, "ab")}},
+				},
+				"Note": []map[string]any{wantFieldEntry(note, "abc")},
+			},
+		},
+		{
+			name:   "JSON pointer",
+			policy: errorsx.ValidationPolicy{Mode: errorsx.ValidationJSONPointer},
+			want: map[string]any{
+				"/Items/0/Name": []map[string]any{wantFieldEntry(name0, "")},
+				"/Items/1/Name": []map[string]any{wantFieldEntry(name1, "ab")},
+				"/Note":         []map[string]any{wantFieldEntry(note, "abc")},
+			},
+		},
+		{
+			name:   "redacted values",
+			policy: errorsx.ValidationPolicy{RedactValues: true},
+			want: map[string]any{
+				"Order.Items[0].Name": []map[string]any{wantFieldEntry(name0, errorsx.RedactedValue)},
+				"Order.Items[1].Name": []map[string]any{wantFieldEntry(name1, errorsx.RedactedValue)},
+				"Order.Note":          []map[string]any{wantFieldEntry(note, errorsx.RedactedValue)},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			errX := errorsx.NewWithError(validationErr, "foo", errorsx.WithValidationPolicy(tc.policy))
+			assert.Equal(t, tc.want, errX.Fields("validation_errors")["validation_errors"])
+		})
+	}
+
+	t.Run("several tags per field", func(t *testing.T) {
+		t.Parallel()
+		type Order struct {
+			Note string `validate:"numeric"`
+		}
+
+		noteErr := validate.Struct(Order{Note: "abc"}).(validator.ValidationErrors)
+		errX := errorsx.NewWithError(validationErr, "foo").Wrap(noteErr)
+
+		got := errX.Fields("validation_errors")["validation_errors"].(map[string]any)
+		assert.Equal(t, []map[string]any{
+			wantFieldEntry(note, "abc"),
+			wantFieldEntry(noteErr[0], "abc"),
+		}, got["Order.Note"])
+	})
+
+	t.Run("nested field with failing children", func(t *testing.T) {
+		t.Parallel()
+		type Order struct {
+			Items []Item `validate:"max=1"`
+		}
+
+		itemsErr := validate.Struct(Order{Items: make([]Item, 2)}).(validator.ValidationErrors)
+		errX := errorsx.NewWithError(
+			itemsErr, "foo",
+			errorsx.WithValidationPolicy(errorsx.ValidationPolicy{Mode: errorsx.ValidationNested}),
+		).Wrap(validationErr)
+
+		got := errX.Fields("validation_errors")["validation_errors"].(map[string]any)
+		items := got["Items"].(map[string]any)
+		assert.Equal(t, []map[string]any{wantFieldEntry(itemsErr[0], make([]Item, 2))}, items["_errors"])
+		assert.Contains(t, items, "0")
+		assert.Contains(t, items, "1")
+	})
+}
+
+func TestSetValidationPolicy(t *testing.T) {
+	defer errorsx.SetValidationPolicy(errorsx.GetValidationPolicy())
+
+	p := errorsx.ValidationPolicy{Mode: errorsx.ValidationJSONPointer}
+	errorsx.SetValidationPolicy(p)
+	assert.Equal(t, p, errorsx.GetValidationPolicy())
+
+	type User struct {
+		Name string `validate:"required"`
+	}
+
+	errX := errorsx.NewWithError(validator.New().Struct(User{}), "foo")
+	want := errX.Fields("validation_errors")
+	assert.Contains(t, want["validation_errors"], "/Name")
+
+	data, err := errorsx.EncodeJSON(errX)
+	require.NoError(t, err)
+	decoded, err := errorsx.DecodeJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, want, decoded.Fields("validation_errors"))
+
+	data, err = json.Marshal(errorsx.NewProblem(errX, nil))
+	require.NoError(t, err)
+	parsed, err := errorsx.ParseProblem(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Contains(t, parsed.Fields("validation_errors")["validation_errors"], "/Name")
+}
+
+func TestValidationErrorX_WrappedValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	type User struct {
+		Name  string `validate:"required"`
+		Email string `validate:"required,email"`
+	}
+
+	validate := validator.New()
+	nameErr := validate.StructPartial(User{}, "Name").(validator.ValidationErrors)
+	emailErr := validate.StructPartial(User{Email: "x"}, "Email").(validator.ValidationErrors)
+
+	tt := []struct {
+		name string
+		errX errorsx.ErrorX
+		want validator.ValidationErrors
+	}{
+		{
+			name: "fmt.Errorf",
+			errX: errorsx.NewWithError(fmt.Errorf("bind: %w", nameErr), "foo"),
+			want: nameErr,
+		},
+		{
+			name: "errors.Join",
+			errX: errorsx.NewWithError(errors.Join(errors.New("bar"), nameErr, fmt.Errorf("bind: %w", emailErr)), "foo"),
+			want: append(append(validator.ValidationErrors{}, nameErr...), emailErr...),
+		},
+		{
+			name: "Wrap",
+			errX: errorsx.New("foo").Wrap(fmt.Errorf("bind: %w", emailErr)),
+			want: emailErr,
+		},
+		{
+			name: "ErrorX cause",
+			errX: errorsx.NewWithError(errorsx.NewWithError(nameErr, "bar"), "foo"),
+			want: nameErr,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := errorsx.FieldErrors(tc.errX)
+			assert.True(t, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("Fields", func(t *testing.T) {
+		t.Parallel()
+		errX := errorsx.NewWithError(errors.Join(nameErr, fmt.Errorf("bind: %w", emailErr)), "foo")
+		assert.Equal(t, map[string]any{
+			"User.Name":  []map[string]any{wantFieldEntry(nameErr[0], "")},
+			"User.Email": []map[string]any{wantFieldEntry(emailErr[0], "x")},
+		}, errX.Fields("validation_errors")["validation_errors"])
+	})
+}
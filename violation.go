@@ -0,0 +1,145 @@
+package errorsx
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldViolation is a validation failure of any origin, such as a hand-made
+// check, another validation library or a JSON schema.
+type FieldViolation struct {
+	// Path locates the field, such as "items[0].name". Unlike the
+	// namespaces of go-playground/validator it has no top-level struct.
+	Path string
+	// Rule names the failed rule, such as "required".
+	Rule string
+	// Message describes the failure.
+	Message string
+	// Params holds the parameters of the rule, such as the bounds of a
+	// length check.
+	Params []string
+	// Value is the offending value.
+	Value any
+}
+
+// ValidationBuilder collects FieldViolations into a validation error that
+// every constructor turns into a validation layer, the same way it does
+// with validator.ValidationErrors.
+type ValidationBuilder struct {
+	errs validator.ValidationErrors
+}
+
+// NewValidation returns an empty ValidationBuilder.
+func NewValidation() *ValidationBuilder {
+	return &ValidationBuilder{}
+}
+
+// Add records a violation of rule by the field at path.
+func (b *ValidationBuilder) Add(path, rule, message string, params ...string) *ValidationBuilder {
+	return b.AddViolation(FieldViolation{Path: path, Rule: rule, Message: message, Params: params})
+}
+
+// AddViolation records v.
+func (b *ValidationBuilder) AddViolation(v FieldViolation) *ValidationBuilder {
+	b.errs = append(b.errs, &violation{v: v, path: splitNamespace(v.Path)})
+	return b
+}
+
+// Err returns the recorded violations as validator.ValidationErrors, or nil
+// when none were recorded.
+func (b *ValidationBuilder) Err() error {
+	if len(b.errs) == 0 {
+		return nil
+	}
+
+	return b.errs
+}
+
+// Violations returns the validation failures of err, as FieldErrors does,
+// converted to FieldViolations.
+func Violations(err error) []FieldViolation {
+	fieldErrs, _ := FieldErrors(err)
+	violations := make([]FieldViolation, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		if v, ok := fe.(*violation); ok {
+			violations = append(violations, v.v)
+			continue
+		}
+
+		var params []string
+		if p := fe.Param(); p != "" {
+			params = strings.Fields(p)
+		}
+
+		violations = append(violations, FieldViolation{
+			Path:    FieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: fe.Error(),
+			Params:  params,
+			Value:   fe.Value(),
+		})
+	}
+
+	return violations
+}
+
+// FromJSONError turns the *json.UnmarshalTypeError and *json.SyntaxError
+// values found in the chain of err into validation errors, under the "type"
+// and "syntax" rules. Other errors are returned unchanged.
+func FromJSONError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return NewValidation().AddViolation(FieldViolation{
+			Path:    typeErr.Field,
+			Rule:    "type",
+			Message: typeErr.Error(),
+			Params:  []string{typeErr.Type.String()},
+			Value:   typeErr.Value,
+		}).Err()
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return NewValidation().
+			Add("", "syntax", syntaxErr.Error(), strconv.FormatInt(syntaxErr.Offset, 10)).
+			Err()
+	}
+
+	return err
+}
+
+// violation implements validator.FieldError for a FieldViolation.
+type violation struct {
+	v    FieldViolation
+	path []string
+}
+
+var _ validator.FieldError = (*violation)(nil)
+
+func (v *violation) Tag() string             { return v.v.Rule }
+func (v *violation) ActualTag() string       { return v.v.Rule }
+func (v *violation) Namespace() string       { return v.v.Path }
+func (v *violation) StructNamespace() string { return v.v.Path }
+func (v *violation) Field() string           { return v.path[len(v.path)-1] }
+func (v *violation) StructField() string     { return v.Field() }
+func (v *violation) Value() any              { return v.v.Value }
+func (v *violation) Param() string           { return strings.Join(v.v.Params, " ") }
+func (v *violation) Kind() reflect.Kind      { return reflect.Invalid }
+func (v *violation) Type() reflect.Type      { return nil }
+func (v *violation) Error() string           { return v.v.Message }
+
+func (v *violation) Translate(ut.Translator) string {
+	return v.v.Message
+}
+
+// Path returns the segments of the path of v, which has no top-level struct
+// to leave out.
+func (v *violation) Path() []string {
+	return v.path
+}
@@ -0,0 +1,148 @@
+package errorsx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/caioreix/errorsx"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationBuilder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, errorsx.NewValidation().Err())
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		t.Parallel()
+		err := errorsx.NewValidation().
+			Add("items[0].name", "required", "name is required").
+			AddViolation(errorsx.FieldViolation{
+				Path:    "items[1].name",
+				Rule:    "length",
+				Message: "name must have 3 to 10 characters",
+				Params:  []string{"3", "10"},
+				Value:   "ab",
+			}).
+			Err()
+
+		errX := errorsx.NewHTTPWithError(err, http.StatusBadRequest, "foo")
+		assert.Equal(t, map[string]any{
+			"items[0].name": []map[string]any{{
+				"tag":              "required",
+				"param":            "",
+				"value":            nil,
+				"namespace":        "items[0].name",
+				"struct_namespace": "items[0].name",
+				"message":          "name is required",
+			}},
+			"items[1].name": []map[string]any{{
+				"tag":              "length",
+				"param":            "3 10",
+				"value":            "ab",
+				"namespace":        "items[1].name",
+				"struct_namespace": "items[1].name",
+				"message":          "name must have 3 to 10 characters",
+			}},
+		}, errX.Fields("validation_errors")["validation_errors"])
+
+		fieldErrs, ok := errorsx.FieldErrors(errX)
+		require.True(t, ok)
+		assert.Equal(t, "name", fieldErrs[0].Field())
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+		err := errorsx.NewValidation().Add("items[0].name", "required", "name is required").Err()
+		errX := errorsx.NewWithError(err, "foo",
+			errorsx.WithValidationPolicy(errorsx.ValidationPolicy{Mode: errorsx.ValidationJSONPointer}))
+
+		assert.Contains(t, errX.Fields("validation_errors")["validation_errors"], "/items/0/name")
+	})
+}
+
+func TestViolations(t *testing.T) {
+	t.Parallel()
+
+	type Item struct {
+		Name string `validate:"oneof=foo bar"`
+	}
+
+	type Order struct {
+		Items []Item `validate:"dive"`
+	}
+
+	validationErr := validator.New().Struct(Order{Items: []Item{{Name: "baz"}}})
+	built := errorsx.FieldViolation{Path: "note", Rule: "required", Message: "note is required"}
+
+	errX := errorsx.NewWithError(validationErr, "foo").
+		Wrap(errorsx.NewValidation().AddViolation(built).Err())
+
+	assert.Equal(t, []errorsx.FieldViolation{
+		{
+			Path:    "Items[0].Name",
+			Rule:    "oneof",
+			Message: validationErr.(validator.ValidationErrors)[0].Error(),
+			Params:  []string{"foo", "bar"},
+			Value:   "baz",
+		},
+		built,
+	}, errorsx.Violations(errX))
+
+	assert.Empty(t, errorsx.Violations(errors.New("foo")))
+
+	t.Run("top-level failure", func(t *testing.T) {
+		t.Parallel()
+		var name string
+		err := validator.New().Var(name, "required")
+
+		got := errorsx.Violations(errorsx.NewWithError(err, "foo"))
+		require.Len(t, got, 1)
+		assert.Empty(t, got[0].Path)
+	})
+}
+
+func TestFromJSONError(t *testing.T) {
+	t.Parallel()
+
+	type Order struct {
+		Count int `json:"count"`
+	}
+
+	t.Run("UnmarshalTypeError", func(t *testing.T) {
+		t.Parallel()
+		var o Order
+		jsonErr := json.Unmarshal([]byte(`{"count":"one"}`), &o)
+
+		got := errorsx.Violations(errorsx.NewWithError(errorsx.FromJSONError(jsonErr), "foo"))
+		require.Len(t, got, 1)
+		assert.Equal(t, "count", got[0].Path)
+		assert.Equal(t, "type", got[0].Rule)
+		assert.Equal(t, []string{"int"}, got[0].Params)
+		assert.Equal(t, jsonErr.Error(), got[0].Message)
+	})
+
+	t.Run("SyntaxError", func(t *testing.T) {
+		t.Parallel()
+		var o Order
+		jsonErr := json.Unmarshal([]byte(`{"count":`), &o)
+
+		got := errorsx.Violations(errorsx.NewWithError(errorsx.FromJSONError(jsonErr), "foo"))
+		require.Len(t, got, 1)
+		assert.Equal(t, "syntax", got[0].Rule)
+		assert.Equal(t, jsonErr.Error(), got[0].Message)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		t.Parallel()
+		err := errors.New("foo")
+		assert.Equal(t, err, errorsx.FromJSONError(err))
+	})
+}